@@ -22,6 +22,7 @@ import (
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/api"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tracing"
 	"log"
 	"os"
 	"os/signal"
@@ -43,7 +44,13 @@ func main() {
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	ctrl, err := pkg.NewController(config)
+	err = tracing.Init(ctx, config)
+	if err != nil {
+		debug.PrintStack()
+		log.Fatal("FATAL:", err)
+	}
+
+	ctrl, err := pkg.NewController(ctx, config)
 	if err != nil {
 		debug.PrintStack()
 		log.Fatal("FATAL:", err)
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/api/util"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
+	"github.com/julienschmidt/httprouter"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+func init() {
+	endpoints = append(endpoints, AdminEndpoints)
+}
+
+// AdminEndpoints are restricted to tokens with the admin role and ignore the hub permissions
+func AdminEndpoints(router *httprouter.Router, config configuration.Config, ctrl *controller.Controller) {
+	//optional query parameters: 'hub_id' (may be repeated) limits the listed hubs, 'owner_id' limits the result to hubs of this user,
+	//'limit' (default 100) and 'offset' page through the hubs; hubs that could not be read are listed with an 'error'
+	router.GET("/admin/deployments", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		query := request.URL.Query()
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		var limit, offset int64
+		if query.Has("limit") {
+			limit, err = strconv.ParseInt(query.Get("limit"), 10, 64)
+			if err != nil {
+				util.Error(writer, request, err, http.StatusBadRequest)
+				return
+			}
+		}
+		if query.Has("offset") {
+			offset, err = strconv.ParseInt(query.Get("offset"), 10, 64)
+			if err != nil || offset < 0 {
+				util.Error(writer, request, errors.New("invalid offset"), http.StatusBadRequest)
+				return
+			}
+		}
+		result, err, code := ctrl.AdminListDeployments(request.Context(), token, query["hub_id"], query.Get("owner_id"), limit, offset)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.GET("/admin/deployments/:hubId/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.AdminGetDeployment(request.Context(), token, hubId, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	//force-removes the deployment, e.g. to clean up deployments of hubs that are broken or no longer exist;
+	//camunda deployments that could not be removed are reported in 'errors' of the response
+	router.DELETE("/admin/deployments/:hubId/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.AdminRemoveDeployment(request.Context(), token, hubId, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
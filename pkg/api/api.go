@@ -18,6 +18,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/api/util"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
@@ -35,7 +36,7 @@ var endpoints []func(*httprouter.Router, configuration.Config, *controller.Contr
 func Start(config configuration.Config, ctx context.Context, ctrl *controller.Controller) (err error) {
 	log.Println("start api on " + config.ApiPort)
 	router := Router(config, ctrl)
-	handler := accesslog.New(util.NewCors(router))
+	handler := util.NewTracing(accesslog.New(util.NewRequestId(util.NewCors(util.NewRateLimit(util.NewMetrics(router), router, config), config))), router)
 	server := &http.Server{Addr: ":" + config.ApiPort, Handler: handler, WriteTimeout: 10 * time.Second, ReadTimeout: 2 * time.Second, ReadHeaderTimeout: 2 * time.Second}
 	go func() {
 		log.Println("listening on ", server.Addr)
@@ -53,8 +54,14 @@ func Start(config configuration.Config, ctx context.Context, ctrl *controller.Co
 	return nil
 }
 
-func Router(config configuration.Config, ctrl *controller.Controller) http.Handler {
+func Router(config configuration.Config, ctrl *controller.Controller) *httprouter.Router {
 	router := httprouter.New()
+	router.NotFound = http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		util.Error(writer, request, errors.New("not found"), http.StatusNotFound)
+	})
+	router.MethodNotAllowed = http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		util.Error(writer, request, errors.New("method not allowed"), http.StatusMethodNotAllowed)
+	})
 	log.Println("add heart beat endpoint")
 	router.GET("/", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 		writer.WriteHeader(http.StatusOK)
@@ -18,17 +18,22 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/SENERGY-Platform/process-deployment/lib/auth"
 	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
 	"github.com/SENERGY-Platform/process-deployment/lib/model/messages"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/api/util"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
 	"github.com/julienschmidt/httprouter"
+	"io"
 	"log"
+	"mime"
 	"net/http"
 	"net/url"
 	"strconv"
-	"time"
 )
 
 func init() {
@@ -45,15 +50,15 @@ func DeploymentEndpoints(router *httprouter.Router, config configuration.Config,
 			if config.Debug {
 				log.Println("ERROR:", err)
 			}
-			http.Error(writer, err.Error(), http.StatusBadRequest)
+			util.Error(writer, request, err, http.StatusBadRequest)
 			return
 		}
-		result, err, code := ctrl.PrepareDeployment(token, hubId, msg.Xml, msg.Svg)
+		result, err, code := ctrl.PrepareDeployment(request.Context(), token, hubId, msg.Xml, msg.Svg)
 		if err != nil {
 			if config.Debug {
 				log.Println("ERROR:", err)
 			}
-			http.Error(writer, err.Error(), code)
+			util.Error(writer, request, err, code)
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -69,20 +74,17 @@ func DeploymentEndpoints(router *httprouter.Router, config configuration.Config,
 			if config.Debug {
 				log.Println("ERROR:", err)
 			}
-			http.Error(writer, err.Error(), code)
+			util.Error(writer, request, err, code)
 			return
 		}
-		start := time.Now()
-		result, err, code := ctrl.PrepareDeployment(token, hubId, process.BpmnXml, process.SvgXml)
+		result, err, code := ctrl.PrepareDeployment(request.Context(), token, hubId, process.BpmnXml, process.SvgXml)
 		if err != nil {
 			if config.Debug {
 				log.Println("ERROR:", err)
 			}
-			http.Error(writer, err.Error(), code)
+			util.Error(writer, request, err, code)
 			return
 		}
-		dur := time.Now().Sub(start)
-		log.Println("DEBUG: prepare deployment complete time:", dur, dur.Milliseconds())
 		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
 		json.NewEncoder(writer).Encode(result)
 	})
@@ -95,24 +97,269 @@ func DeploymentEndpoints(router *httprouter.Router, config configuration.Config,
 		err := json.NewDecoder(request.Body).Decode(&deployment)
 		if err != nil {
 			log.Println("ERROR: unable to parse request", err)
-			http.Error(writer, err.Error(), http.StatusBadRequest)
+			util.Error(writer, request, err, http.StatusBadRequest)
 			return
 		}
-		optionals := map[string]bool{}
-		optionalServiceStr := request.URL.Query().Get("optional_service_selection")
-		if optionalServiceStr != "" {
-			optionals["service"], err = strconv.ParseBool(optionalServiceStr)
+		optionals, err := parseOptionals(request.URL.Query())
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.CreateDeployment(request.Context(), token, hubId, deployment, source, optionals)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.GET("/deployments/:hubId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ListDeployments(request.Context(), token, hubId)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.GET("/deployments/:hubId/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.GetDeployment(request.Context(), token, hubId, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.PUT("/deployments/:hubId/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token := request.Header.Get("Authorization")
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		source := request.URL.Query().Get("source")
+		deployment := deploymentmodel.Deployment{}
+		err := json.NewDecoder(request.Body).Decode(&deployment)
+		if err != nil {
+			log.Println("ERROR: unable to parse request", err)
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		optionals, err := parseOptionals(request.URL.Query())
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.UpdateDeployment(request.Context(), token, hubId, id, deployment, source, optionals)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	//expects a json-patch (RFC 6902) document that is applied to the currently deployed deployment model
+	router.PATCH("/deployments/:hubId/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token := request.Header.Get("Authorization")
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		source := request.URL.Query().Get("source")
+		patch, err := io.ReadAll(request.Body)
+		if err != nil {
+			log.Println("ERROR: unable to read request", err)
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		optionals, err := parseOptionals(request.URL.Query())
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.PatchDeployment(request.Context(), token, hubId, id, patch, source, optionals)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.POST("/deployment-validations/:hubId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token := request.Header.Get("Authorization")
+		hubId := params.ByName("hubId")
+		source := request.URL.Query().Get("source")
+		deployment := deploymentmodel.Deployment{}
+		err := json.NewDecoder(request.Body).Decode(&deployment)
+		if err != nil {
+			log.Println("ERROR: unable to parse request", err)
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		optionals, err := parseOptionals(request.URL.Query())
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ValidateDeployment(request.Context(), token, hubId, deployment, source, optionals)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	//expects a bundle created by the export endpoint (application/zip)
+	//or a multipart/form-data upload with the files 'bpmn' and 'svg'
+	//if a selection can not be resolved, nothing is deployed and the unmatched elements are listed
+	router.POST("/deployment-imports/:hubId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		source := request.URL.Query().Get("source")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		optionals, err := parseOptionals(request.URL.Query())
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		request.Body = http.MaxBytesReader(writer, request.Body, maxImportSize)
+		var result model.DeploymentTransferResult
+		var code int
+		mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+		if mediaType == "multipart/form-data" {
+			err = request.ParseMultipartForm(maxImportSize)
+			if err != nil {
+				util.Error(writer, request, err, http.StatusBadRequest)
+				return
+			}
+			var xml, svg string
+			xml, err = readFormFile(request, "bpmn")
+			if err != nil {
+				util.Error(writer, request, err, http.StatusBadRequest)
+				return
+			}
+			svg, err = readFormFile(request, "svg")
+			if err != nil && !errors.Is(err, http.ErrMissingFile) {
+				util.Error(writer, request, err, http.StatusBadRequest)
+				return
+			}
+			result, err, code = ctrl.ImportDeploymentDiagram(request.Context(), token, hubId, xml, svg, source, optionals)
+		} else {
+			var bundle []byte
+			bundle, err = io.ReadAll(request.Body)
+			if err != nil {
+				util.Error(writer, request, err, http.StatusBadRequest)
+				return
+			}
+			result, err, code = ctrl.ImportDeploymentBundle(request.Context(), token, hubId, bundle, source, optionals)
+		}
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.GET("/deployments/:hubId/:id/export", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ExportDeployment(request.Context(), token, hubId, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/zip")
+		writer.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": id + ".zip"}))
+		_, err = writer.Write(result)
+		if err != nil {
+			log.Println("ERROR: unable to write response", err)
+		}
+	})
+
+	//copies the deployment to the hub given by the query parameter 'target_hub'
+	//with move=true the source deployment is removed after the copy has been deployed
+	router.POST("/deployments/:hubId/:id/copy", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		source := request.URL.Query().Get("source")
+		targetHubId := request.URL.Query().Get("target_hub")
+		if targetHubId == "" {
+			util.Error(writer, request, errors.New("missing target_hub query parameter"), http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		move := false
+		moveStr := request.URL.Query().Get("move")
+		if moveStr != "" {
+			move, err = strconv.ParseBool(moveStr)
 			if err != nil {
-				http.Error(writer, err.Error(), http.StatusBadRequest)
+				util.Error(writer, request, err, http.StatusBadRequest)
 				return
 			}
 		}
-		result, err, code := ctrl.CreateDeployment(token, hubId, deployment, source, optionals)
+		optionals, err := parseOptionals(request.URL.Query())
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.CopyDeployment(request.Context(), token, hubId, id, targetHubId, move, source, optionals)
 		if err != nil {
 			if config.Debug {
 				log.Println("ERROR:", err)
 			}
-			http.Error(writer, err.Error(), code)
+			util.Error(writer, request, err, code)
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -124,54 +371,373 @@ func DeploymentEndpoints(router *httprouter.Router, config configuration.Config,
 		id := params.ByName("id")
 		token, err := auth.GetParsedToken(request)
 		if err != nil {
-			http.Error(writer, err.Error(), http.StatusBadRequest)
+			util.Error(writer, request, err, http.StatusBadRequest)
 			return
 		}
-		err, code := ctrl.RemoveDeployment(token, hubId, id)
+		result, err, code := ctrl.RemoveDeployment(request.Context(), token, hubId, id)
 		if err != nil {
-			http.Error(writer, err.Error(), code)
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	//compares the deployment with the deployment given by the query parameters:
+	//'hub' and optionally 'deployment' select a deployment of another hub (the deployment id defaults to the id in the path);
+	//otherwise 'from_version' is compared with 'to_version', where a missing version stands for the currently deployed model
+	router.GET("/deployments/:hubId/:id/diff", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		query := request.URL.Query()
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		var result model.DeploymentDiff
+		var code int
+		if otherHubId := query.Get("hub"); otherHubId != "" {
+			otherId := query.Get("deployment")
+			if otherId == "" {
+				otherId = id
+			}
+			result, err, code = ctrl.DiffDeploymentWithHub(request.Context(), token, hubId, id, otherHubId, otherId)
+		} else {
+			versions := map[string]int{}
+			for _, key := range []string{"from_version", "to_version"} {
+				if query.Has(key) {
+					versions[key], err = strconv.Atoi(query.Get(key))
+					if err != nil {
+						util.Error(writer, request, fmt.Errorf("%v: %w", key, err), http.StatusBadRequest)
+						return
+					}
+				}
+			}
+			result, err, code = ctrl.DiffDeploymentVersions(request.Context(), token, hubId, id, versions["from_version"], versions["to_version"])
+		}
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	//compares the deployment with the proposed deployment in the request body, e.g. before an update
+	router.POST("/deployments/:hubId/:id/diff", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		proposed := deploymentmodel.Deployment{}
+		err = json.NewDecoder(request.Body).Decode(&proposed)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.DiffDeploymentWithProposal(request.Context(), token, hubId, id, proposed)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.GET("/deployments/:hubId/:id/instances", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ListProcessInstances(request.Context(), token, hubId, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	//stops the running process instance
+	router.DELETE("/deployments/:hubId/:id/instances/:instanceId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		instanceId := params.ByName("instanceId")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		err, code := ctrl.StopProcessInstance(request.Context(), token, hubId, id, instanceId)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(true)
+	})
+
+	router.GET("/deployments/:hubId/:id/history", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ListHistoricProcessInstances(request.Context(), token, hubId, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.DELETE("/deployments/:hubId/:id/history/:instanceId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		instanceId := params.ByName("instanceId")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		err, code := ctrl.DeleteHistoricProcessInstance(request.Context(), token, hubId, id, instanceId)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
 		json.NewEncoder(writer).Encode(true)
 	})
 
+	router.GET("/deployments/:hubId/:id/incidents", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ListIncidents(request.Context(), token, hubId, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.GET("/deployments/:hubId/:id/start-parameters", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.GetStartParameterSchema(request.Context(), token, hubId, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/schema+json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	//query parameters are used as start variables, except the optional 'business_key'
 	router.GET("/deployments/:hubId/:id/start", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 		hubId := params.ByName("hubId")
 		id := params.ByName("id")
 		token, err := auth.GetParsedToken(request)
 		if err != nil {
-			http.Error(writer, err.Error(), http.StatusBadRequest)
+			util.Error(writer, request, err, http.StatusBadRequest)
 			return
 		}
 
-		err, code := ctrl.StartDeployment(token, hubId, id, request.URL.Query())
+		values := request.URL.Query()
+		query := map[string]string{}
+		for key := range values {
+			query[key] = values.Get(key)
+		}
+		businessKey := query["business_key"]
+		delete(query, "business_key")
+		result, err, code := ctrl.StartDeploymentWithQuery(request.Context(), token, hubId, id, businessKey, query)
 		if err != nil {
-			http.Error(writer, err.Error(), code)
+			util.Error(writer, request, err, code)
 			return
 		}
 		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
-		err = json.NewEncoder(writer).Encode(true)
+		err = json.NewEncoder(writer).Encode(result)
 		if err != nil {
 			log.Println("ERROR: unable to encode response", err)
 		}
 		return
 	})
+
+	//expects a json object of start variables; values may be of any json type (including nested objects)
+	//an optional business key may be passed as query parameter 'business_key'
+	router.POST("/deployments/:hubId/:id/start", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		inputs := map[string]interface{}{}
+		decoder := json.NewDecoder(request.Body)
+		decoder.UseNumber() //keep numbers as sent, e.g. large integers
+		err = decoder.Decode(&inputs)
+		if err != nil && !errors.Is(err, io.EOF) {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.StartDeployment(request.Context(), token, hubId, id, request.URL.Query().Get("business_key"), inputs)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			log.Println("ERROR: unable to encode response", err)
+		}
+	})
+
+	router.GET("/deployments/:hubId/:id/versions", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ListDeploymentVersions(request.Context(), token, hubId, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.GET("/deployments/:hubId/:id/versions/:version", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		version, err := strconv.Atoi(params.ByName("version"))
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.GetDeploymentVersion(request.Context(), token, hubId, id, version)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	//redeploys the model of the given version; the rollback is recorded as a new version
+	router.POST("/deployments/:hubId/:id/versions/:version/rollback", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		source := request.URL.Query().Get("source")
+		version, err := strconv.Atoi(params.ByName("version"))
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		optionals, err := parseOptionals(request.URL.Query())
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.RollbackDeployment(request.Context(), token, hubId, id, version, source, optionals)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
 }
 
-func parseQueryParameter(query url.Values) (result map[string]interface{}) {
-	if len(query) == 0 {
-		return map[string]interface{}{}
+const maxImportSize = 32 << 20
+
+func readFormFile(request *http.Request, name string) (result string, err error) {
+	file, _, err := request.FormFile(name)
+	if err != nil {
+		return result, fmt.Errorf("%v: %w", name, err)
 	}
-	result = map[string]interface{}{}
-	for key, _ := range query {
-		var val interface{}
-		temp := query.Get(key)
-		err := json.Unmarshal([]byte(temp), &val)
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	return string(content), err
+}
+
+func parseOptionals(query url.Values) (optionals map[string]bool, err error) {
+	optionals = map[string]bool{}
+	optionalServiceStr := query.Get("optional_service_selection")
+	if optionalServiceStr != "" {
+		optionals["service"], err = strconv.ParseBool(optionalServiceStr)
 		if err != nil {
-			val = temp
+			return optionals, err
 		}
-		result[key] = val
 	}
-	return result
+	return optionals, nil
 }
@@ -17,6 +17,7 @@
 package api
 
 import (
+	"encoding/json"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
 	"github.com/julienschmidt/httprouter"
@@ -35,4 +36,21 @@ func HealthEndpoints(router *httprouter.Router, config configuration.Config, ctr
 		log.Println("INFO: /health", err, string(msg))
 		writer.WriteHeader(http.StatusOK)
 	})
+
+	router.GET("/health/live", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	//responds with 503 if an upstream service is not reachable
+	router.GET("/health/ready", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		result := ctrl.CheckReadiness(request.Context())
+		if !result.Ready && config.Debug {
+			log.Printf("DEBUG: not ready %#v\n", result.Dependencies)
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if !result.Ready {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(writer).Encode(result)
+	})
 }
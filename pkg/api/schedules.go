@@ -0,0 +1,145 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/api/util"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/julienschmidt/httprouter"
+	"log"
+	"net/http"
+)
+
+func init() {
+	endpoints = append(endpoints, ScheduleEndpoints)
+}
+
+func ScheduleEndpoints(router *httprouter.Router, config configuration.Config, ctrl *controller.Controller) {
+	router.GET("/schedules/:hubId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ListSchedules(token, hubId)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.POST("/schedules/:hubId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		schedule := model.Schedule{}
+		err = json.NewDecoder(request.Body).Decode(&schedule)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.CreateSchedule(request.Context(), token, hubId, schedule)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.GET("/schedules/:hubId/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.GetSchedule(token, hubId, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.PUT("/schedules/:hubId/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		schedule := model.Schedule{}
+		err = json.NewDecoder(request.Body).Decode(&schedule)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.UpdateSchedule(request.Context(), token, hubId, id, schedule)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.DELETE("/schedules/:hubId/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		hubId := params.ByName("hubId")
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		err, code := ctrl.DeleteSchedule(token, hubId, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(true)
+	})
+}
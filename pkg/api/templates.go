@@ -0,0 +1,168 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/api/util"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/julienschmidt/httprouter"
+	"log"
+	"net/http"
+)
+
+func init() {
+	endpoints = append(endpoints, TemplateEndpoints)
+}
+
+func TemplateEndpoints(router *httprouter.Router, config configuration.Config, ctrl *controller.Controller) {
+	router.GET("/templates", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ListTemplates(token)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	//expects a template with a prepared deployment; device selections of the deployment are discarded, only the filter criteria are stored
+	router.POST("/templates", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		template := model.DeploymentTemplate{}
+		err = json.NewDecoder(request.Body).Decode(&template)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.CreateTemplate(token, template)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.GET("/templates/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.GetTemplate(token, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.PUT("/templates/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		template := model.DeploymentTemplate{}
+		err = json.NewDecoder(request.Body).Decode(&template)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.UpdateTemplate(token, id, template)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+
+	router.DELETE("/templates/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		err, code := ctrl.DeleteTemplate(token, id)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(true)
+	})
+
+	//deploys the template to the hub; if a selection can not be resolved, nothing is deployed and the unmatched elements are listed
+	router.POST("/templates/:id/apply/:hubId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		hubId := params.ByName("hubId")
+		source := request.URL.Query().Get("source")
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		optionals, err := parseOptionals(request.URL.Query())
+		if err != nil {
+			util.Error(writer, request, err, http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ApplyTemplate(request.Context(), token, id, hubId, source, optionals)
+		if err != nil {
+			if config.Debug {
+				log.Println("ERROR:", err)
+			}
+			util.Error(writer, request, err, code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
@@ -16,29 +16,113 @@
 
 package util
 
-import "net/http"
+import (
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"net/http"
+	"strconv"
+	"strings"
+)
 
-func NewCors(handler http.Handler) *CorsMiddleware {
-	return &CorsMiddleware{handler: handler}
+var defaultCorsHeaders = []string{"Origin", "X-Requested-With", "Content-Type", "Accept", "Authorization", RequestIdHeader}
+var defaultCorsMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions}
+
+// NewCors adds CORS headers to responses for origins in config.CorsAllowedOrigins.
+// requests of other origins are handled without CORS headers, so that browsers reject them.
+func NewCors(handler http.Handler, config configuration.Config) *CorsMiddleware {
+	headers := config.CorsAllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCorsHeaders
+	}
+	methods := config.CorsAllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCorsMethods
+	}
+	result := &CorsMiddleware{
+		handler:          handler,
+		headers:          strings.Join(headers, ", "),
+		methods:          strings.Join(methods, ", "),
+		allowCredentials: config.CorsAllowCredentials,
+	}
+	for _, origin := range config.CorsAllowedOrigins {
+		origin = strings.ToLower(strings.TrimSpace(origin))
+		if origin == "*" {
+			result.allowAll = true
+		} else if origin != "" {
+			result.origins = append(result.origins, origin)
+		}
+	}
+	if config.CorsMaxAge > 0 {
+		result.maxAge = strconv.FormatInt(config.CorsMaxAge, 10)
+	}
+	return result
 }
 
 type CorsMiddleware struct {
-	handler http.Handler
+	handler          http.Handler
+	origins          []string //may contain wildcards (e.g. https://*.example.com)
+	allowAll         bool     //set by the origin "*"
+	headers          string
+	methods          string
+	maxAge           string
+	allowCredentials bool
 }
 
 func (this *CorsMiddleware) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	origin := req.Header.Get("Origin")
-	if origin == "" {
-		origin = "*"
+	res.Header().Add("Vary", "Origin")
+	explicit := origin != "" && this.isAllowedExplicitly(origin)
+	allowed := explicit || (origin != "" && this.allowAll)
+	if explicit {
+		res.Header().Set("Access-Control-Allow-Origin", origin)
+		if this.allowCredentials {
+			res.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+	} else if allowed {
+		//browsers reject credentials for the wildcard origin
+		res.Header().Set("Access-Control-Allow-Origin", "*")
 	}
-	res.Header().Set("Access-Control-Allow-Origin", origin)
-	res.Header().Set("Access-Control-Allow-Headers", "Origin, X-Requested-With, Content-Type, Accept, authorization, Authorization")
-	res.Header().Set("Access-Control-Allow-Credentials", "true")
-	res.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
 
-	if req.Method == "OPTIONS" {
+	//preflight requests are answered without calling the handler
+	if req.Method == http.MethodOptions && req.Header.Get("Access-Control-Request-Method") != "" {
+		if allowed {
+			res.Header().Set("Access-Control-Allow-Headers", this.headers)
+			res.Header().Set("Access-Control-Allow-Methods", this.methods)
+			if this.maxAge != "" {
+				res.Header().Set("Access-Control-Max-Age", this.maxAge)
+			}
+		}
 		res.WriteHeader(http.StatusOK)
-	} else {
-		this.handler.ServeHTTP(res, req)
+		return
+	}
+	this.handler.ServeHTTP(res, req)
+}
+
+func (this *CorsMiddleware) isAllowedExplicitly(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, pattern := range this.origins {
+		if matchWildcard(pattern, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// every '*' in the pattern matches any (possibly empty) sequence of characters
+func matchWildcard(pattern string, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	if len(parts) == 1 {
+		return value == pattern
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(value, part)
+		if index < 0 {
+			return false
+		}
+		value = value[index+len(part):]
 	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
 }
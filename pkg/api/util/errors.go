@@ -0,0 +1,103 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/google/uuid"
+	"log"
+	"net/http"
+)
+
+const RequestIdHeader = "X-Request-Id"
+
+// NewRequestId ensures that every request has a request id, which is also returned as response header
+func NewRequestId(handler http.Handler) *RequestIdMiddleware {
+	return &RequestIdMiddleware{handler: handler}
+}
+
+type RequestIdMiddleware struct {
+	handler http.Handler
+}
+
+func (this *RequestIdMiddleware) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	id := req.Header.Get(RequestIdHeader)
+	if id == "" {
+		id = uuid.NewString()
+		req.Header.Set(RequestIdHeader, id)
+	}
+	res.Header().Set(RequestIdHeader, id)
+	this.handler.ServeHTTP(res, req)
+}
+
+// Error responds with a model.Problem.
+// details like a specific error code, the upstream service or the bpmn id are taken from a wrapped *model.Error.
+func Error(writer http.ResponseWriter, request *http.Request, err error, code int) {
+	if code < 400 {
+		code = http.StatusInternalServerError
+	}
+	problem := model.Problem{
+		Title:     http.StatusText(code),
+		Status:    code,
+		Detail:    err.Error(),
+		Code:      defaultErrorCode(code),
+		RequestId: request.Header.Get(RequestIdHeader),
+	}
+	var details *model.Error
+	if errors.As(err, &details) {
+		if details.Code != "" {
+			problem.Code = details.Code
+		}
+		problem.Upstream = details.Upstream
+		problem.BpmnId = details.BpmnId
+		problem.StartedInstances = details.StartedInstances
+	}
+	writer.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+	writer.Header().Set("X-Content-Type-Options", "nosniff")
+	writer.WriteHeader(code)
+	err = json.NewEncoder(writer).Encode(problem)
+	if err != nil {
+		log.Println("ERROR: unable to encode error response", err)
+	}
+}
+
+func defaultErrorCode(status int) model.ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return model.ErrorCodeBadRequest
+	case http.StatusUnauthorized:
+		return model.ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return model.ErrorCodeForbidden
+	case http.StatusNotFound:
+		return model.ErrorCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return model.ErrorCodeMethodNotAllowed
+	case http.StatusConflict:
+		return model.ErrorCodeConflict
+	case http.StatusTooManyRequests:
+		return model.ErrorCodeTooManyRequests
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return model.ErrorCodeUpstreamUnavailable
+	}
+	if status < 500 {
+		return model.ErrorCodeBadRequest
+	}
+	return model.ErrorCodeInternal
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/metrics"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func NewMetrics(router *httprouter.Router) *MetricsMiddleware {
+	return &MetricsMiddleware{router: router}
+}
+
+// MetricsMiddleware records requests by their route (e.g. /deployments/:hubId/:id) to keep the number of label values small
+type MetricsMiddleware struct {
+	router *httprouter.Router
+}
+
+func (this *MetricsMiddleware) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	start := time.Now()
+	recorder := &statusRecorder{ResponseWriter: res, status: http.StatusOK}
+	this.router.ServeHTTP(recorder, req)
+	route := Route(this.router, req)
+	metrics.ApiRequestDuration.WithLabelValues(req.Method, route).Observe(time.Since(start).Seconds())
+	metrics.ApiRequests.WithLabelValues(req.Method, route, strconv.Itoa(recorder.status)).Inc()
+}
+
+// Route reconstructs the route of the request by replacing the path segments of the matched parameters with their names
+func Route(router *httprouter.Router, req *http.Request) string {
+	handle, params, _ := router.Lookup(req.Method, req.URL.Path)
+	if handle == nil {
+		return "unknown"
+	}
+	segments := strings.Split(req.URL.Path, "/")
+	index := 0
+	for _, param := range params {
+		for ; index < len(segments); index++ {
+			if segments[index] == param.Value {
+				segments[index] = ":" + param.Key
+				index++
+				break
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (this *statusRecorder) WriteHeader(status int) {
+	this.status = status
+	this.ResponseWriter.WriteHeader(status)
+}
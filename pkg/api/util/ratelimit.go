@@ -0,0 +1,178 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"errors"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/metrics"
+	"github.com/julienschmidt/httprouter"
+	"golang.org/x/time/rate"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NewRateLimit limits the requests per user and per hub (path parameter 'hubId') and the number of concurrent preparations.
+// limits with a rate or count of 0 are disabled.
+// rejected requests get 429 with a Retry-After header.
+func NewRateLimit(handler http.Handler, router *httprouter.Router, config configuration.Config) *RateLimitMiddleware {
+	result := &RateLimitMiddleware{handler: handler, router: router}
+	if config.RateLimitUserRequestsPerSecond > 0 {
+		result.users = newLimiters(config.RateLimitUserRequestsPerSecond, config.RateLimitUserBurst)
+	}
+	if config.RateLimitHubRequestsPerSecond > 0 {
+		result.hubs = newLimiters(config.RateLimitHubRequestsPerSecond, config.RateLimitHubBurst)
+	}
+	if config.MaxConcurrentPrepares > 0 {
+		result.prepares = make(chan struct{}, config.MaxConcurrentPrepares)
+	}
+	return result
+}
+
+type RateLimitMiddleware struct {
+	handler  http.Handler
+	router   *httprouter.Router
+	users    *limiters     //nil if disabled
+	hubs     *limiters     //nil if disabled
+	prepares chan struct{} //semaphore of running preparations; nil if disabled
+}
+
+// clients are asked to retry rejected preparations after this duration
+const prepareRetryAfter = time.Second
+
+func (this *RateLimitMiddleware) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	_, params, _ := this.router.Lookup(req.Method, req.URL.Path)
+	now := time.Now()
+	reservations := map[string]*rate.Reservation{}
+	if this.users != nil {
+		token, err := auth.GetParsedToken(req)
+		if err == nil && token.GetUserId() != "" {
+			reservations["user"] = this.users.reserve(token.GetUserId(), now)
+		}
+	}
+	if hubId := params.ByName("hubId"); this.hubs != nil && hubId != "" {
+		reservations["hub"] = this.hubs.reserve(hubId, now)
+	}
+	limit, retryAfter := "", time.Duration(0)
+	for name, reservation := range reservations {
+		if delay := reservation.DelayFrom(now); delay > retryAfter {
+			limit, retryAfter = name, delay
+		}
+	}
+	if retryAfter > 0 {
+		//the reservations are returned, so that rejected requests don't count against the limits
+		for _, reservation := range reservations {
+			reservation.CancelAt(now)
+		}
+		this.reject(res, req, limit, retryAfter)
+		return
+	}
+	if this.prepares != nil && isPreparation(req, params) {
+		select {
+		case this.prepares <- struct{}{}:
+			defer func() { <-this.prepares }()
+		default:
+			this.reject(res, req, "prepare", prepareRetryAfter)
+			return
+		}
+	}
+	this.handler.ServeHTTP(res, req)
+}
+
+func (this *RateLimitMiddleware) reject(res http.ResponseWriter, req *http.Request, limit string, retryAfter time.Duration) {
+	metrics.RateLimitedRequests.WithLabelValues(limit).Inc()
+	res.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	message := "too many requests for this " + limit
+	if limit == "prepare" {
+		message = "too many concurrent deployment preparations"
+	}
+	Error(res, req, errors.New(message), http.StatusTooManyRequests)
+}
+
+// preparations resolve the device selections of every task and event (see controller.PrepareDeployment).
+// imports, copies and applied templates resolve the selections for their target hub the same way.
+func isPreparation(req *http.Request, params httprouter.Params) bool {
+	if strings.HasPrefix(req.URL.Path, "/prepared-deployments/") {
+		return true
+	}
+	if req.Method != http.MethodPost || params.ByName("hubId") == "" {
+		return false
+	}
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/deployment-imports/"): //POST /deployment-imports/:hubId
+		return true
+	case strings.HasPrefix(req.URL.Path, "/deployments/") && strings.HasSuffix(req.URL.Path, "/copy"): //POST /deployments/:hubId/:id/copy
+		return true
+	case strings.HasPrefix(req.URL.Path, "/templates/"): //POST /templates/:id/apply/:hubId
+		return true
+	default:
+		return false
+	}
+}
+
+// limiters that have not been used for this duration are removed; their bucket would be full anyway
+const limiterIdleTimeout = 10 * time.Minute
+
+type limiters struct {
+	mux         sync.Mutex
+	limit       rate.Limit
+	burst       int
+	entries     map[string]*limiterEntry
+	lastCleanup time.Time
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+func newLimiters(requestsPerSecond float64, burst int64) *limiters {
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiters{
+		limit:       rate.Limit(requestsPerSecond),
+		burst:       int(burst),
+		entries:     map[string]*limiterEntry{},
+		lastCleanup: time.Now(),
+	}
+}
+
+func (this *limiters) reserve(key string, now time.Time) *rate.Reservation {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if now.Sub(this.lastCleanup) > limiterIdleTimeout {
+		for k, entry := range this.entries {
+			if now.Sub(entry.lastUsed) > limiterIdleTimeout {
+				delete(this.entries, k)
+			}
+		}
+		this.lastCleanup = now
+	}
+	entry, ok := this.entries[key]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(this.limit, this.burst)}
+		this.entries[key] = entry
+	}
+	entry.lastUsed = now
+	return entry.limiter.ReserveN(now, 1)
+}
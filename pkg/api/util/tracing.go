@@ -0,0 +1,31 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"github.com/julienschmidt/httprouter"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"net/http"
+)
+
+// NewTracing starts a server span for every request, continuing the trace context of the request headers.
+// spans are named by the route of the request (see Route).
+func NewTracing(handler http.Handler, router *httprouter.Router) http.Handler {
+	return otelhttp.NewHandler(handler, "api", otelhttp.WithSpanNameFormatter(func(operation string, req *http.Request) string {
+		return req.Method + " " + Route(router, req)
+	}))
+}
@@ -37,8 +37,47 @@ type ConfigStruct struct {
 	NotificationUrl    string `json:"notification_url"`
 	ProcessSyncUrl     string `json:"process_sync_url"`
 
+	//timeout of device-selection requests (e.g. "30s"); empty string disables the timeout
+	DeviceSelectionTimeout string `json:"device_selection_timeout"`
+
+	//duration after which a not yet confirmed deployment change is reported as stale; empty string disables the stale state
+	DeploymentStaleDuration string `json:"deployment_stale_duration"`
+
 	EnableDeviceGroupsForTasks  bool `json:"enable_device_groups_for_tasks"`
 	EnableDeviceGroupsForEvents bool `json:"enable_device_groups_for_events"`
+
+	MongoUrl                string `json:"mongo_url"`
+	MongoTable              string `json:"mongo_table"`
+	MongoScheduleCollection string `json:"mongo_schedule_collection"`
+	MongoVersionCollection  string `json:"mongo_version_collection"`
+	MongoTemplateCollection string `json:"mongo_template_collection"`
+
+	//interval in which due schedules are started; empty string disables the scheduler
+	ScheduleCheckInterval string `json:"schedule_check_interval"`
+
+	//used to exchange tokens of schedule owners
+	AuthEndpoint     string `json:"auth_endpoint"`
+	AuthClientId     string `json:"auth_client_id"`
+	AuthClientSecret string `json:"auth_client_secret"`
+
+	//origins allowed to use the api; entries may contain wildcards (e.g. https://*.example.com), "*" allows every origin without credentials
+	CorsAllowedOrigins   []string `json:"cors_allowed_origins"`
+	CorsAllowedHeaders   []string `json:"cors_allowed_headers"` //empty list uses the default headers
+	CorsAllowedMethods   []string `json:"cors_allowed_methods"` //empty list uses the default methods
+	CorsAllowCredentials bool     `json:"cors_allow_credentials"`
+	CorsMaxAge           int64    `json:"cors_max_age"` //seconds preflight responses may be cached; 0 omits the header
+
+	//requests per second and burst size per user and per hub; a rate of 0 disables the limit
+	RateLimitUserRequestsPerSecond float64 `json:"rate_limit_user_requests_per_second"`
+	RateLimitUserBurst             int64   `json:"rate_limit_user_burst"`
+	RateLimitHubRequestsPerSecond  float64 `json:"rate_limit_hub_requests_per_second"`
+	RateLimitHubBurst              int64   `json:"rate_limit_hub_burst"`
+
+	//max number of concurrent deployment preparations, imports, copies and template applications; 0 disables the limit
+	MaxConcurrentPrepares int64 `json:"max_concurrent_prepares"`
+
+	//otlp/http endpoint spans are exported to (e.g. http://otel-collector:4318); empty string disables the export
+	OtelExporterEndpoint string `json:"otel_exporter_endpoint"`
 }
 
 type Config = *ConfigStruct
@@ -0,0 +1,174 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/metrics"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// number of hubs that are read in parallel by AdminListDeployments
+const adminListConcurrency = 10
+
+// used by AdminListDeployments if no limit is given
+const adminListDefaultLimit = 100
+
+// AdminListDeployments lists the fog deployments of the given hubs, regardless of the hub permissions of the admin.
+// if no hub is given, the deployments of the hubs known to permissions-v2 are listed.
+// limit and offset select the hubs; a limit of 0 selects adminListDefaultLimit hubs.
+// if ownerId is set, only hubs of this owner are listed.
+// hubs that can not be read are listed with an error, instead of failing the whole request.
+func (this *Controller) AdminListDeployments(ctx context.Context, token auth.Token, hubIds []string, ownerId string, limit int64, offset int64) (result []model.HubDeployments, err error, code int) {
+	err, code = checkAdmin(token)
+	if err != nil {
+		return result, err, code
+	}
+	if limit <= 0 {
+		limit = adminListDefaultLimit
+	}
+	if len(hubIds) == 0 {
+		start := time.Now()
+		hubIds, err, code = this.permissions.AdminListResourceIds(token.Jwt(), hubTopic, permv2.ListOptions{Limit: limit, Offset: offset})
+		metrics.ObserveUpstreamCall(upstreamPermissions, "list-hubs", start, code, err)
+		if err != nil {
+			return result, &model.Error{Code: model.ErrorCodeUpstream, Upstream: upstreamPermissions, Err: err}, http.StatusBadGateway
+		}
+	} else {
+		hubIds = hubIds[min(offset, int64(len(hubIds))):]
+		hubIds = hubIds[:min(limit, int64(len(hubIds)))]
+	}
+
+	hubs := make([]model.HubDeployments, len(hubIds))
+	wg := sync.WaitGroup{}
+	semaphore := make(chan struct{}, adminListConcurrency)
+	for i, hubId := range hubIds {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			hub := model.HubDeployments{HubId: hubId, OwnerId: this.getHubOwner(ctx, token, hubId), Deployments: []model.FogDeployment{}}
+			if ownerId == "" || hub.OwnerId == ownerId {
+				deployments, err, _ := this.listDeployments(ctx, token.Jwt(), hubId)
+				if err != nil {
+					hub.Error = err.Error()
+				} else {
+					hub.Deployments = deployments
+				}
+			}
+			hubs[i] = hub
+		}()
+	}
+	wg.Wait()
+
+	result = []model.HubDeployments{}
+	for _, hub := range hubs {
+		if ownerId == "" || hub.OwnerId == ownerId {
+			result = append(result, hub)
+		}
+	}
+	behalf := ownerId
+	if behalf == "" {
+		behalf = "all users"
+	}
+	logAdminAction(token, behalf, "list deployments of", len(result), "hubs")
+	return result, nil, http.StatusOK
+}
+
+// AdminGetDeployment returns the fog deployment, regardless of the hub permissions of the admin
+func (this *Controller) AdminGetDeployment(ctx context.Context, token auth.Token, hubId string, deploymentId string) (result []model.FogDeploymentInfo, err error, code int) {
+	err, code = checkAdmin(token)
+	if err != nil {
+		return result, err, code
+	}
+	logAdminAction(token, this.getHubOwner(ctx, token, hubId), "read deployment", deploymentId, "of hub", hubId)
+	return this.getDeployment(ctx, token.Jwt(), hubId, deploymentId)
+}
+
+// AdminRemoveDeployment removes the fog deployment, regardless of the hub permissions of the admin.
+// meant to clean up broken deployments; works also for hubs that no longer exist in the device-repository.
+// camunda deployments that can not be removed don't stop the removal of the others and are reported in the result.
+func (this *Controller) AdminRemoveDeployment(ctx context.Context, token auth.Token, hubId string, deploymentId string) (result model.AdminRemoveResult, err error, code int) {
+	err, code = checkAdmin(token)
+	if err != nil {
+		return result, err, code
+	}
+	logAdminAction(token, this.getHubOwner(ctx, token, hubId), "remove deployment", deploymentId, "of hub", hubId)
+	metadata, err, code := this.processSync.Metadata(ctx, token.Jwt(), hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	if len(metadata) == 0 {
+		return result, errors.New("deployment not found"), http.StatusNotFound
+	}
+	result = model.AdminRemoveResult{
+		DeploymentStateInfo: model.DeploymentStateInfo{Id: deploymentId, State: model.DeploymentStatePendingDelete},
+		Removed:             []string{},
+	}
+	for _, m := range metadata {
+		err, _ = this.processSync.Remove(ctx, token.Jwt(), hubId, m.CamundaDeploymentId)
+		if err != nil {
+			log.Println("ERROR: unable to force remove camunda deployment", hubId, deploymentId, m.CamundaDeploymentId, err)
+			result.Errors = append(result.Errors, m.CamundaDeploymentId+": "+err.Error())
+			continue
+		}
+		result.Removed = append(result.Removed, m.CamundaDeploymentId)
+	}
+	err = this.db.RemoveDeploymentVersions(hubId, deploymentId)
+	if err != nil {
+		log.Println("ERROR: unable to remove deployment versions", hubId, deploymentId, err)
+		result.Errors = append(result.Errors, "versions: "+err.Error())
+	}
+	return result, nil, http.StatusOK
+}
+
+func checkAdmin(token auth.Token) (err error, code int) {
+	if !token.IsAdmin() {
+		return &model.Error{Code: model.ErrorCodeForbidden, Err: errors.New("admin role required")}, http.StatusForbidden
+	}
+	return nil, http.StatusOK
+}
+
+// returns an empty string if the hub could not be read (e.g. because it has already been removed)
+func (this *Controller) getHubOwner(ctx context.Context, token auth.Token, hubId string) string {
+	hubRepo, ok := this.deviceRepoFactory(ctx, this.config, this.reusedDeviceRepo, hubId).(HubRepo)
+	if !ok {
+		return ""
+	}
+	hub, err, _ := hubRepo.GetHub(token.Jwt(), hubId)
+	if err != nil {
+		log.Println("WARNING: unable to read owner of hub", hubId, err)
+		return ""
+	}
+	return hub.OwnerId
+}
+
+// every admin action is logged with the admin and the user on whose behalf the admin acted
+func logAdminAction(token auth.Token, ownerId string, action ...interface{}) {
+	if ownerId == "" {
+		ownerId = "unknown"
+	}
+	log.Println(append([]interface{}{"ADMIN:", token.GetUserId(), "on behalf of", ownerId + ":"}, action...)...)
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"io"
+	"net/http"
+	"time"
+)
+
+// file names inside of deployment bundles
+const (
+	BundleManifestFile        = "manifest.json"
+	BundleDeploymentFile      = "deployment.json"
+	BundleBpmnFile            = "diagram.bpmn"
+	BundleSvgFile             = "diagram.svg"
+	BundleStartParametersFile = "start-parameters.json"
+)
+
+var bundleFiles = map[string]bool{
+	BundleManifestFile:        true,
+	BundleDeploymentFile:      true,
+	BundleBpmnFile:            true,
+	BundleSvgFile:             true,
+	BundleStartParametersFile: true,
+}
+
+// maximal uncompressed size of all files of a bundle together
+const maxBundleSize = 32 << 20
+
+// ExportDeployment packages the deployment as zip archive
+func (this *Controller) ExportDeployment(ctx context.Context, token auth.Token, hubId string, deploymentId string) (result []byte, err error, code int) {
+	current, err, code := this.GetDeployment(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	deployment := current[0].Deployment
+	parameters, err, code := this.getStartParameters(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	manifest := model.DeploymentBundleManifest{
+		FormatVersion:  model.DeploymentBundleFormatVersion,
+		SourceHubId:    hubId,
+		DeploymentId:   deploymentId,
+		DeploymentName: deployment.Name,
+		ExportDate:     time.Now().UTC(),
+	}
+	versions, err := this.db.ListDeploymentVersions(hubId, deploymentId)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if len(versions) > 0 {
+		manifest.DeploymentVersion = versions[len(versions)-1].Version
+	}
+
+	buf := bytes.NewBuffer(nil)
+	archive := zip.NewWriter(buf)
+	files := []struct {
+		name    string
+		content interface{}
+	}{
+		{name: BundleManifestFile, content: manifest},
+		{name: BundleDeploymentFile, content: deployment},
+		{name: BundleStartParametersFile, content: parameters},
+		{name: BundleBpmnFile, content: deployment.Diagram.XmlRaw},
+		{name: BundleSvgFile, content: deployment.Diagram.Svg},
+	}
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+		if str, ok := file.content.(string); ok {
+			_, err = io.WriteString(w, str)
+		} else {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "    ")
+			err = encoder.Encode(file.content)
+		}
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+	}
+	err = archive.Close()
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return buf.Bytes(), nil, http.StatusOK
+}
+
+// ImportDeploymentBundle deploys a bundle created by ExportDeployment to the hub.
+// the device selections of the bundled deployment are resolved again against the devices of the hub (see CopyDeployment).
+// bundles without deployment.json are prepared from the bpmn and svg files (see ImportDeploymentDiagram).
+func (this *Controller) ImportDeploymentBundle(ctx context.Context, token auth.Token, hubId string, bundle []byte, source string, optionals map[string]bool) (result model.DeploymentTransferResult, err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Execute)
+	if err != nil {
+		return result, err, code
+	}
+	files, err := readBundle(bundle)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	if manifestFile, ok := files[BundleManifestFile]; ok {
+		manifest := model.DeploymentBundleManifest{}
+		err = json.Unmarshal(manifestFile, &manifest)
+		if err != nil {
+			return result, fmt.Errorf("invalid %v: %w", BundleManifestFile, err), http.StatusBadRequest
+		}
+		if manifest.FormatVersion > model.DeploymentBundleFormatVersion {
+			return result, fmt.Errorf("unsupported bundle format version %v", manifest.FormatVersion), http.StatusBadRequest
+		}
+	}
+	xml := string(files[BundleBpmnFile])
+	svg := string(files[BundleSvgFile])
+	if xml == "" {
+		return result, errors.New("missing " + BundleBpmnFile + " in bundle"), http.StatusBadRequest
+	}
+	deploymentFile, ok := files[BundleDeploymentFile]
+	if !ok {
+		return this.ImportDeploymentDiagram(ctx, token, hubId, xml, svg, source, optionals)
+	}
+	deployment := deploymentmodel.Deployment{}
+	err = json.Unmarshal(deploymentFile, &deployment)
+	if err != nil {
+		return result, fmt.Errorf("invalid %v: %w", BundleDeploymentFile, err), http.StatusBadRequest
+	}
+	deployment.Id = ""
+	deployment.Diagram.XmlRaw = xml
+	deployment.Diagram.Svg = svg
+	previous := getSelections(deployment)
+	err = this.ReuseCloudDeploymentWithNewDeviceRepo(ctx, hubId).SetDeploymentOptions(token, &deployment)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	this.SetExecutableFlag(&deployment)
+	return this.deployWithResolvedSelections(ctx, token, hubId, deployment, previous, source, optionals)
+}
+
+// ImportDeploymentDiagram prepares a deployment of the bpmn for the hub and deploys it,
+// if every task and event can be matched to exactly one device of the hub.
+func (this *Controller) ImportDeploymentDiagram(ctx context.Context, token auth.Token, hubId string, xml string, svg string, source string, optionals map[string]bool) (result model.DeploymentTransferResult, err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Execute)
+	if err != nil {
+		return result, err, code
+	}
+	deployment, err, code := this.PrepareDeployment(ctx, token.Jwt(), hubId, xml, svg)
+	if err != nil {
+		return result, err, code
+	}
+	return this.deployWithResolvedSelections(ctx, token, hubId, deployment, map[string]deploymentmodel.Selection{}, source, optionals)
+}
+
+// resolves the selections of the deployment (see resolveSelections) and deploys it, if every selection could be resolved.
+// expects the SelectionOptions of the deployment to be set for the hub.
+func (this *Controller) deployWithResolvedSelections(ctx context.Context, token auth.Token, hubId string, deployment deploymentmodel.Deployment, previous map[string]deploymentmodel.Selection, source string, optionals map[string]bool) (result model.DeploymentTransferResult, err error, code int) {
+	result.Unmatched = resolveSelections(&deployment, previous)
+	result.Deployment = deployment
+	if len(result.Unmatched) > 0 {
+		return result, nil, http.StatusOK
+	}
+	created, err, code := this.CreateDeployment(ctx, token.Jwt(), hubId, deployment, source, optionals)
+	if err != nil {
+		return result, err, code
+	}
+	result.Deployed = true
+	result.Deployment = created.Deployment
+	result.State = created.State
+	return result, nil, http.StatusOK
+}
+
+// returns the content of the archive, indexed by file name.
+// only the known bundle files are accepted; the uncompressed size is counted while reading
+// and not taken from the archive headers, which may be forged.
+func readBundle(bundle []byte) (result map[string][]byte, err error) {
+	archive, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	if err != nil {
+		return result, fmt.Errorf("invalid bundle: %w", err)
+	}
+	result = map[string][]byte{}
+	remaining := int64(maxBundleSize)
+	for _, file := range archive.File {
+		if !bundleFiles[file.Name] {
+			return result, fmt.Errorf("unexpected file %v in bundle", file.Name)
+		}
+		if _, ok := result[file.Name]; ok {
+			return result, fmt.Errorf("duplicate file %v in bundle", file.Name)
+		}
+		f, err := file.Open()
+		if err != nil {
+			return result, fmt.Errorf("invalid bundle: %w", err)
+		}
+		content, err := io.ReadAll(io.LimitReader(f, remaining+1))
+		f.Close()
+		if err != nil {
+			return result, fmt.Errorf("invalid bundle: %w", err)
+		}
+		remaining = remaining - int64(len(content))
+		if remaining < 0 {
+			return result, fmt.Errorf("bundle exceeds the maximal uncompressed size of %v bytes", maxBundleSize)
+		}
+		result[file.Name] = content
+	}
+	return result, nil
+}
@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"errors"
+	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
 	"github.com/SENERGY-Platform/process-deployment/lib/auth"
 	"github.com/SENERGY-Platform/process-deployment/lib/config"
 	"github.com/SENERGY-Platform/process-deployment/lib/ctrl"
@@ -32,7 +33,7 @@ import (
 	"github.com/SENERGY-Platform/process-deployment/lib/processrepo"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
-	"net/url"
+	"time"
 )
 
 type Controller struct {
@@ -43,23 +44,57 @@ type Controller struct {
 	deploymentStringifier interfaces.DeploymentStringifier
 	deviceRepoFactory     DeviceRepoFactory
 	processSync           ProcessSync
+	permissions           permv2.Client
 	reusedDeviceRepo      interfaces.Devices
+	db                    Database
+	staleDuration         time.Duration
+	scheduleCheckInterval time.Duration
 }
 
 type ProcessSync interface {
-	Deploy(token string, hubId string, deployment deploymentmodel.Deployment) error
-	Remove(token string, hubId string, id string) (err error, code int)
-	Metadata(token string, hubId string, deploymentId string) (result []model.DeploymentMetadata, err error, code int)
-	Start(token string, hubId string, deploymentId string, inputs url.Values) (error, int)
+	Deploy(ctx context.Context, token string, hubId string, deployment deploymentmodel.Deployment) error
+	Remove(ctx context.Context, token string, hubId string, id string) (err error, code int)
+	Metadata(ctx context.Context, token string, hubId string, deploymentId string) (result []model.DeploymentMetadata, err error, code int)
+	Start(ctx context.Context, token string, hubId string, deploymentId string, businessKey string, inputs map[string]interface{}) (result model.ProcessInstance, err error, code int)
+	ProcessDefinitions(ctx context.Context, token string, hubId string) (result []model.ProcessDefinition, err error, code int)
+	ProcessInstances(ctx context.Context, token string, hubId string) (result []model.ProcessInstance, err error, code int)
+	HistoricProcessInstances(ctx context.Context, token string, hubId string) (result []model.HistoricProcessInstance, err error, code int)
+	StopProcessInstance(ctx context.Context, token string, hubId string, instanceId string) (err error, code int)
+	DeleteHistoricProcessInstance(ctx context.Context, token string, hubId string, instanceId string) (err error, code int)
+	Incidents(ctx context.Context, token string, hubId string) (result []model.Incident, err error, code int)
+}
+
+type Database interface {
+	ListSchedules(userId string, hubId string) (result []model.Schedule, err error)
+	GetSchedule(id string) (result model.Schedule, exists bool, err error)
+	SetSchedule(schedule model.Schedule) error
+	RemoveSchedule(id string) error
+	ListDueSchedules(now time.Time) (result []model.Schedule, err error)
+	ClaimScheduleRun(schedule model.Schedule, nextRun time.Time, lastRun time.Time) (claimed bool, err error)
+	SetScheduleRunResult(id string, lastError string) error
+	AddDeploymentVersion(version model.DeploymentVersion) (result model.DeploymentVersion, err error)
+	ListDeploymentVersions(hubId string, deploymentId string) (result []model.DeploymentVersion, err error)
+	GetDeploymentVersion(hubId string, deploymentId string, version int) (result model.DeploymentVersion, exists bool, err error)
+	RemoveDeploymentVersions(hubId string, deploymentId string) error
+	ListTemplates(userId string) (result []model.DeploymentTemplate, err error)
+	GetTemplate(id string) (result model.DeploymentTemplate, exists bool, err error)
+	SetTemplate(template model.DeploymentTemplate) error
+	RemoveTemplate(id string) error
 }
 
 type ProcessRepo interface {
 	GetProcessModel(token string, id string) (result processmodel.ProcessModel, err error, errCode int)
 }
 
-type DeviceRepoFactory func(config configuration.Config, reuse interfaces.Devices, hubId string) interfaces.Devices
+// HubRepo is implemented by device repositories that can read hubs (e.g. devicerepo.DeviceRepo)
+type HubRepo interface {
+	GetHub(token string, id string) (result devicemodel.Hub, err error, code int)
+}
 
-func New(conf configuration.Config, processSync ProcessSync, deviceRepoFactory DeviceRepoFactory) (*Controller, error) {
+// DeviceRepoFactory creates a device repository for a single deployment pipeline run; ctx is the parent of its spans and requests
+type DeviceRepoFactory func(ctx context.Context, config configuration.Config, reuse interfaces.Devices, hubId string) interfaces.Devices
+
+func New(conf configuration.Config, processSync ProcessSync, deviceRepoFactory DeviceRepoFactory, db Database) (*Controller, error) {
 	reusedConfig := &config.ConfigStruct{
 		ApiPort:                     conf.ApiPort,
 		DeviceRepoUrl:               conf.DeviceRepoUrl,
@@ -82,6 +117,28 @@ func New(conf configuration.Config, processSync ProcessSync, deviceRepoFactory D
 	if err != nil {
 		return nil, err
 	}
+
+	var staleDuration time.Duration
+	if conf.DeploymentStaleDuration != "" {
+		staleDuration, err = time.ParseDuration(conf.DeploymentStaleDuration)
+		if err != nil {
+			return nil, err
+		}
+	}
+	//used by the device repository, checked here to fail on startup
+	if conf.DeviceSelectionTimeout != "" {
+		_, err = time.ParseDuration(conf.DeviceSelectionTimeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var scheduleCheckInterval time.Duration
+	if conf.ScheduleCheckInterval != "" {
+		scheduleCheckInterval, err = time.ParseDuration(conf.ScheduleCheckInterval)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &Controller{
 		config:           conf,
 		reusedConfig:     reusedConfig,
@@ -90,35 +147,62 @@ func New(conf configuration.Config, processSync ProcessSync, deviceRepoFactory D
 		deploymentStringifier: stringifier.New(reusedConfig, func(token auth.Token, aspectNodeId string) (aspectNode devicemodel.AspectNode, err error) {
 			return reusedDeviceRepo.GetAspectNode(token, aspectNodeId)
 		}),
-		deviceRepoFactory: deviceRepoFactory,
-		processSync:       processSync,
-		reusedDeviceRepo:  reusedDeviceRepo,
+		deviceRepoFactory:     deviceRepoFactory,
+		processSync:           processSync,
+		permissions:           permv2.New(conf.PermissionsV2Url),
+		reusedDeviceRepo:      reusedDeviceRepo,
+		db:                    db,
+		staleDuration:         staleDuration,
+		scheduleCheckInterval: scheduleCheckInterval,
 	}, nil
 }
 
-func (this *Controller) ReuseCloudDeploymentWithProcessSync(token string, hubId string) *ctrl.Ctrl {
+func (this *Controller) ReuseCloudDeploymentWithProcessSync(ctx context.Context, token string, hubId string) *ctrl.Ctrl {
+	return this.ReuseCloudDeploymentWithProcessSyncForId(ctx, token, hubId, "")
+}
+
+// ReuseCloudDeploymentWithProcessSyncForId deploys with the given deploymentId instead of a newly generated one
+func (this *Controller) ReuseCloudDeploymentWithProcessSyncForId(ctx context.Context, token string, hubId string, deploymentId string) *ctrl.Ctrl {
+	result, _ := ctrl.New(
+		ctx,
+		this.reusedConfig,
+		&SourcingReplacement{
+			token:        token,
+			hubId:        hubId,
+			deploymentId: deploymentId,
+			processSync:  this.processSync,
+		},
+		nil,
+		this.deviceRepoFactory(ctx, this.config, this.reusedDeviceRepo, hubId),
+		nil,
+		ImportsMock{})
+	return result
+}
+
+// ReuseCloudDeploymentForValidation runs the complete deployment pipeline without sending the result to process-sync
+func (this *Controller) ReuseCloudDeploymentForValidation(ctx context.Context, token string, hubId string) *ctrl.Ctrl {
 	result, _ := ctrl.New(
-		context.Background(),
+		ctx,
 		this.reusedConfig,
 		&SourcingReplacement{
-			token:       token,
-			hubId:       hubId,
-			processSync: this.processSync,
+			token:  token,
+			hubId:  hubId,
+			dryRun: true,
 		},
 		nil,
-		this.deviceRepoFactory(this.config, this.reusedDeviceRepo, hubId),
+		this.deviceRepoFactory(ctx, this.config, this.reusedDeviceRepo, hubId),
 		nil,
 		ImportsMock{})
 	return result
 }
 
-func (this *Controller) ReuseCloudDeploymentWithNewDeviceRepo(hubId string) *ctrl.Ctrl {
-	result, _ := ctrl.New(context.Background(), this.reusedConfig, &SourcingReplacement{}, nil, this.deviceRepoFactory(this.config, this.reusedDeviceRepo, hubId), nil, ImportsMock{})
+func (this *Controller) ReuseCloudDeploymentWithNewDeviceRepo(ctx context.Context, hubId string) *ctrl.Ctrl {
+	result, _ := ctrl.New(ctx, this.reusedConfig, &SourcingReplacement{}, nil, this.deviceRepoFactory(ctx, this.config, this.reusedDeviceRepo, hubId), nil, ImportsMock{})
 	return result
 }
 
 func (this *Controller) ReuseCloudDeployment() *ctrl.Ctrl {
-	result, _ := ctrl.New(context.Background(), this.reusedConfig, &SourcingReplacement{}, nil, this.deviceRepoFactory(this.config, this.reusedDeviceRepo, ""), nil, ImportsMock{})
+	result, _ := ctrl.New(context.Background(), this.reusedConfig, &SourcingReplacement{}, nil, this.deviceRepoFactory(context.Background(), this.config, this.reusedDeviceRepo, ""), nil, ImportsMock{})
 	return result
 }
 
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"net/http"
+)
+
+// CopyDeployment recreates a deployment of the source hub on the target hub.
+// device selections are resolved again against the devices of the target hub.
+// if not every selection can be resolved, nothing is deployed and the unmatched tasks and events are reported.
+// if move is true, the source deployment is removed after the copy has been deployed.
+func (this *Controller) CopyDeployment(ctx context.Context, token auth.Token, sourceHubId string, deploymentId string, targetHubId string, move bool, source string, optionals map[string]bool) (result model.DeploymentTransferResult, err error, code int) {
+	if sourceHubId == targetHubId {
+		return result, errors.New("source and target hub must differ"), http.StatusBadRequest
+	}
+	err, code = this.checkHubAccess(token.Jwt(), targetHubId, permv2.Execute)
+	if err != nil {
+		return result, err, code
+	}
+	//checked before the copy is deployed, to not end up with the deployment on both hubs
+	if move {
+		err, code = this.checkHubAccess(token.Jwt(), sourceHubId, permv2.Administrate)
+		if err != nil {
+			return result, err, code
+		}
+	}
+	current, err, code := this.GetDeployment(ctx, token, sourceHubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	deployment := current[0].Deployment
+	previous := getSelections(deployment)
+	err = this.ReuseCloudDeploymentWithNewDeviceRepo(ctx, targetHubId).SetDeploymentOptions(token, &deployment)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	this.SetExecutableFlag(&deployment)
+	result, err, code = this.deployWithResolvedSelections(ctx, token, targetHubId, deployment, previous, source, optionals)
+	if err != nil || !result.Deployed {
+		return result, err, code
+	}
+
+	if move {
+		_, err, code = this.RemoveDeployment(ctx, token, sourceHubId, deploymentId)
+		if err != nil {
+			return result, errors.New("deployment copied to target hub but unable to remove source deployment: " + err.Error()), code
+		}
+	}
+	return result, nil, http.StatusOK
+}
@@ -17,18 +17,38 @@
 package controller
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
 	"github.com/SENERGY-Platform/process-deployment/lib/auth"
 	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/metrics"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tracing"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"log"
 	"net/http"
-	"net/url"
+	"time"
 )
 
-func (this *Controller) PrepareDeployment(token string, hubId string, xml string, svg string) (result deploymentmodel.Deployment, err error, code int) {
+func (this *Controller) PrepareDeployment(ctx context.Context, token string, hubId string, xml string, svg string) (result deploymentmodel.Deployment, err error, code int) {
+	ctx, span := tracing.StartSpan(ctx, "prepare deployment", attribute.String("hub.id", hubId))
+	start := time.Now()
+	defer func() {
+		metrics.PrepareDuration.Observe(time.Since(start).Seconds())
+		tracing.EndSpan(span, err)
+	}()
+	err, code = this.checkHubAccess(token, hubId, permv2.Read)
+	if err != nil {
+		return result, err, code
+	}
 	result, err = this.deploymentParser.PrepareDeployment(xml)
 	if err != nil {
 		return result, err, http.StatusInternalServerError
 	}
-	err = this.ReuseCloudDeploymentWithNewDeviceRepo(hubId).SetDeploymentOptions(auth.Token{Token: token}, &result)
+	err = this.ReuseCloudDeploymentWithNewDeviceRepo(ctx, hubId).SetDeploymentOptions(auth.Token{Token: token}, &result)
 	if err != nil {
 		return result, err, http.StatusInternalServerError
 	}
@@ -41,47 +61,215 @@ func (this *Controller) PrepareDeployment(token string, hubId string, xml string
 	return result, nil, http.StatusOK
 }
 
-func (this *Controller) CreateDeployment(token string, hubId string, deployment deploymentmodel.Deployment, source string, optionals map[string]bool) (result deploymentmodel.Deployment, err error, code int) {
+func (this *Controller) CreateDeployment(ctx context.Context, token string, hubId string, deployment deploymentmodel.Deployment, source string, optionals map[string]bool) (result model.DeploymentWithState, err error, code int) {
+	ctx, span := tracing.StartSpan(ctx, "create deployment", attribute.String("hub.id", hubId))
+	defer func() { tracing.EndSpan(span, err) }()
+	err, code = this.checkHubAccess(token, hubId, permv2.Execute)
+	if err != nil {
+		return result, err, code
+	}
 	jwtToken, err := auth.Parse(token)
 	if err != nil {
 		return result, err, http.StatusInternalServerError
 	}
-	return this.ReuseCloudDeploymentWithProcessSync(token, hubId).
+	result.Deployment, err, code = this.ReuseCloudDeploymentWithProcessSync(ctx, token, hubId).
 		CreateDeployment(
 			jwtToken,
 			deployment,
 			source,
 			optionals)
+	if err != nil {
+		return result, err, code
+	}
+	this.recordDeploymentVersion(jwtToken.GetUserId(), hubId, result.Deployment)
+	result.State = model.DeploymentStatePendingSync
+	return result, nil, code
+}
+
+func (this *Controller) RemoveDeployment(ctx context.Context, token auth.Token, hubId string, deploymentId string) (result model.DeploymentStateInfo, err error, code int) {
+	ctx, span := tracing.StartSpan(ctx, "remove deployment", attribute.String("hub.id", hubId), attribute.String("deployment.id", deploymentId))
+	defer func() { tracing.EndSpan(span, err) }()
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Administrate)
+	if err != nil {
+		return result, err, code
+	}
+	return this.removeDeployment(ctx, token.Jwt(), hubId, deploymentId)
 }
 
-func (this *Controller) RemoveDeployment(token auth.Token, hubId string, deploymentId string) (err error, code int) {
-	metadata, err, code := this.processSync.Metadata(token.Jwt(), hubId, deploymentId)
+func (this *Controller) removeDeployment(ctx context.Context, token string, hubId string, deploymentId string) (result model.DeploymentStateInfo, err error, code int) {
+	metadata, err, code := this.processSync.Metadata(ctx, token, hubId, deploymentId)
 	if err != nil {
-		return err, code
+		return result, err, code
 	}
 	for _, m := range metadata {
-		err, code = this.processSync.Remove(token.Jwt(), hubId, m.CamundaDeploymentId)
+		err, code = this.processSync.Remove(ctx, token, hubId, m.CamundaDeploymentId)
 		if err != nil {
-			return err, code
+			return result, err, code
 		}
 	}
-	return nil, http.StatusOK
+	err = this.db.RemoveDeploymentVersions(hubId, deploymentId)
+	if err != nil {
+		log.Println("ERROR: unable to remove deployment versions", hubId, deploymentId, err)
+	}
+	return model.DeploymentStateInfo{Id: deploymentId, State: model.DeploymentStatePendingDelete}, nil, http.StatusOK
 }
 
 func (this *Controller) SetExecutableFlag(deployment *deploymentmodel.Deployment) {
 	this.ReuseCloudDeployment().SetExecutableFlag(deployment)
 }
 
-func (this *Controller) StartDeployment(token auth.Token, hubId string, deploymentId string, inputs url.Values) (err error, code int) {
-	metadata, err, code := this.processSync.Metadata(token.Jwt(), hubId, deploymentId)
+// StartDeployment starts one process instance per camunda deployment behind the fog deployment.
+// if no business key is given, a new one is generated to correlate the started instances.
+// inputs are checked against and converted to the declared start parameters of the deployment.
+func (this *Controller) StartDeployment(ctx context.Context, token auth.Token, hubId string, deploymentId string, businessKey string, inputs map[string]interface{}) (result model.StartResult, err error, code int) {
+	return this.startDeployment(ctx, token, hubId, deploymentId, businessKey, func(parameters map[string]model.Variable) (map[string]interface{}, error) {
+		if parameters == nil {
+			return inputs, nil
+		}
+		return coerceStartInputs(parameters, inputs)
+	})
+}
+
+// StartDeploymentWithQuery is StartDeployment with inputs given as query parameters.
+// the raw values are converted to the declared start parameter types;
+// deployments without declared start parameters receive the values parsed as json, if possible.
+func (this *Controller) StartDeploymentWithQuery(ctx context.Context, token auth.Token, hubId string, deploymentId string, businessKey string, query map[string]string) (result model.StartResult, err error, code int) {
+	return this.startDeployment(ctx, token, hubId, deploymentId, businessKey, func(parameters map[string]model.Variable) (map[string]interface{}, error) {
+		inputs := map[string]interface{}{}
+		for key, value := range query {
+			if parameters == nil {
+				var parsed interface{}
+				if json.Unmarshal([]byte(value), &parsed) == nil {
+					inputs[key] = parsed
+					continue
+				}
+			}
+			inputs[key] = value
+		}
+		if parameters == nil {
+			return inputs, nil
+		}
+		return coerceStartInputs(parameters, inputs)
+	})
+}
+
+// coerce receives the declared start parameters of the deployment, or nil if none are declared
+func (this *Controller) startDeployment(ctx context.Context, token auth.Token, hubId string, deploymentId string, businessKey string, coerce func(parameters map[string]model.Variable) (map[string]interface{}, error)) (result model.StartResult, err error, code int) {
+	ctx, span := tracing.StartSpan(ctx, "start deployment", attribute.String("hub.id", hubId), attribute.String("deployment.id", deploymentId))
+	defer func() { tracing.EndSpan(span, err) }()
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Execute)
+	if err != nil {
+		return result, err, code
+	}
+	metadata, err, code := this.processSync.Metadata(ctx, token.Jwt(), hubId, deploymentId)
 	if err != nil {
-		return err, code
+		return result, err, code
 	}
+	if len(metadata) == 0 {
+		return result, errors.New("deployment not found"), http.StatusNotFound
+	}
+	inputs, err := coerce(mergeStartParameters(metadata))
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	if businessKey == "" {
+		businessKey = uuid.NewString()
+	}
+	result = model.StartResult{BusinessKey: businessKey, Instances: []model.StartedInstance{}}
 	for _, m := range metadata {
-		err, code = this.processSync.Start(token.Jwt(), hubId, m.CamundaDeploymentId, inputs)
+		instance, err, code := this.processSync.Start(ctx, token.Jwt(), hubId, m.CamundaDeploymentId, businessKey, inputs)
 		if err != nil {
-			return err, code
+			//the already started instances are reported, so that clients are able to stop them
+			return result, withStartedInstances(err, result.Instances), code
 		}
+		result.Instances = append(result.Instances, model.StartedInstance{
+			CamundaDeploymentId: m.CamundaDeploymentId,
+			ProcessInstanceId:   instance.Id,
+		})
 	}
-	return nil, http.StatusOK
+	return result, nil, http.StatusOK
+}
+
+// adds the started instances to the error details, keeping the details of a wrapped *model.Error
+func withStartedInstances(err error, instances []model.StartedInstance) error {
+	if len(instances) == 0 {
+		return err
+	}
+	result := &model.Error{StartedInstances: instances, Err: err}
+	var details *model.Error
+	if errors.As(err, &details) {
+		result.Code = details.Code
+		result.Upstream = details.Upstream
+		result.BpmnId = details.BpmnId
+	}
+	return result
+}
+
+func (this *Controller) ListDeployments(ctx context.Context, token auth.Token, hubId string) (result []model.FogDeployment, err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Read)
+	if err != nil {
+		return result, err, code
+	}
+	return this.listDeployments(ctx, token.Jwt(), hubId)
+}
+
+func (this *Controller) listDeployments(ctx context.Context, token string, hubId string) (result []model.FogDeployment, err error, code int) {
+	metadata, err, code := this.processSync.Metadata(ctx, token, hubId, "")
+	if err != nil {
+		return result, err, code
+	}
+	result = []model.FogDeployment{}
+	index := map[string]int{}
+	for _, m := range metadata {
+		id := m.DeploymentModel.Id
+		i, ok := index[id]
+		if !ok {
+			i = len(result)
+			index[id] = i
+			result = append(result, model.FogDeployment{
+				Id:                   id,
+				Name:                 m.DeploymentModel.Name,
+				CamundaDeploymentIds: []string{},
+			})
+		}
+		element := result[i]
+		element.CamundaDeploymentIds = append(element.CamundaDeploymentIds, m.CamundaDeploymentId)
+		//a fog deployment is only fully synced if every camunda deployment behind it is synced
+		element.IsPlaceholder = element.IsPlaceholder || m.IsPlaceholder
+		element.MarkedForDelete = element.MarkedForDelete || m.MarkedForDelete
+		if m.SyncDate.After(element.SyncDate) {
+			element.SyncDate = m.SyncDate
+		}
+		element.State = combineDeploymentStates(element.State, this.GetDeploymentState(m.SyncInfo))
+		result[i] = element
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) GetDeployment(ctx context.Context, token auth.Token, hubId string, deploymentId string) (result []model.FogDeploymentInfo, err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Read)
+	if err != nil {
+		return result, err, code
+	}
+	return this.getDeployment(ctx, token.Jwt(), hubId, deploymentId)
+}
+
+func (this *Controller) getDeployment(ctx context.Context, token string, hubId string, deploymentId string) (result []model.FogDeploymentInfo, err error, code int) {
+	metadata, err, code := this.processSync.Metadata(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	if len(metadata) == 0 {
+		return result, errors.New("deployment not found"), http.StatusNotFound
+	}
+	result = []model.FogDeploymentInfo{}
+	for _, m := range metadata {
+		result = append(result, model.FogDeploymentInfo{
+			CamundaDeploymentId: m.CamundaDeploymentId,
+			Deployment:          m.DeploymentModel,
+			State:               this.GetDeploymentState(m.SyncInfo),
+			SyncInfo:            m.SyncInfo,
+		})
+	}
+	return result, nil, http.StatusOK
 }
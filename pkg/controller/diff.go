@@ -0,0 +1,232 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+// DiffDeploymentVersions compares two recorded versions of the deployment; version 0 stands for the currently deployed model
+func (this *Controller) DiffDeploymentVersions(ctx context.Context, token auth.Token, hubId string, deploymentId string, fromVersion int, toVersion int) (result model.DeploymentDiff, err error, code int) {
+	from, err, code := this.getDeploymentOrVersion(ctx, token, hubId, deploymentId, fromVersion)
+	if err != nil {
+		return result, err, code
+	}
+	to, err, code := this.getDeploymentOrVersion(ctx, token, hubId, deploymentId, toVersion)
+	if err != nil {
+		return result, err, code
+	}
+	return DiffDeployments(from, to), nil, http.StatusOK
+}
+
+// DiffDeploymentWithHub compares the deployment with a deployment of another hub
+func (this *Controller) DiffDeploymentWithHub(ctx context.Context, token auth.Token, hubId string, deploymentId string, otherHubId string, otherDeploymentId string) (result model.DeploymentDiff, err error, code int) {
+	from, err, code := this.GetDeployment(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	to, err, code := this.GetDeployment(ctx, token, otherHubId, otherDeploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	return DiffDeployments(from[0].Deployment, to[0].Deployment), nil, http.StatusOK
+}
+
+// DiffDeploymentWithProposal compares the deployment with a deployment that has not been deployed yet, e.g. the body of an update
+func (this *Controller) DiffDeploymentWithProposal(ctx context.Context, token auth.Token, hubId string, deploymentId string, proposed deploymentmodel.Deployment) (result model.DeploymentDiff, err error, code int) {
+	current, err, code := this.GetDeployment(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	return DiffDeployments(current[0].Deployment, proposed), nil, http.StatusOK
+}
+
+func (this *Controller) getDeploymentOrVersion(ctx context.Context, token auth.Token, hubId string, deploymentId string, version int) (result deploymentmodel.Deployment, err error, code int) {
+	if version < 0 {
+		return result, errors.New("invalid version"), http.StatusBadRequest
+	}
+	if version == 0 {
+		current, err, code := this.GetDeployment(ctx, token, hubId, deploymentId)
+		if err != nil {
+			return result, err, code
+		}
+		return current[0].Deployment, nil, http.StatusOK
+	}
+	v, err, code := this.GetDeploymentVersion(ctx, token, hubId, deploymentId, version)
+	if err != nil {
+		return result, err, code
+	}
+	return v.Deployment, nil, http.StatusOK
+}
+
+// DiffDeployments compares the settings of two deployments. elements are matched by their bpmn id.
+// ids, versions and diagrams are ignored.
+func DiffDeployments(old deploymentmodel.Deployment, new deploymentmodel.Deployment) (result model.DeploymentDiff) {
+	d := &deploymentDiff{changes: []model.DeploymentChange{}}
+	d.compare("", "name", old.Name, new.Name)
+	d.compare("", "description", old.Description, new.Description)
+	d.compare("", "executable", old.Executable, new.Executable)
+	oldIncidentHandling, newIncidentHandling := deploymentmodel.IncidentHandling{}, deploymentmodel.IncidentHandling{}
+	if old.IncidentHandling != nil {
+		oldIncidentHandling = *old.IncidentHandling
+	}
+	if new.IncidentHandling != nil {
+		newIncidentHandling = *new.IncidentHandling
+	}
+	d.compare("", "incident_handling.restart", oldIncidentHandling.Restart, newIncidentHandling.Restart)
+	d.compare("", "incident_handling.notify", oldIncidentHandling.Notify, newIncidentHandling.Notify)
+
+	oldElements := map[string]deploymentmodel.Element{}
+	for _, element := range old.Elements {
+		oldElements[element.BpmnId] = element
+	}
+	newElements := map[string]bool{}
+	for _, element := range new.Elements {
+		newElements[element.BpmnId] = true
+		oldElement, ok := oldElements[element.BpmnId]
+		if !ok {
+			d.add(element.BpmnId, "element", nil, element.Name)
+			continue
+		}
+		d.compareElements(oldElement, element)
+	}
+	for _, element := range old.Elements {
+		if !newElements[element.BpmnId] {
+			d.add(element.BpmnId, "element", element.Name, nil)
+		}
+	}
+	result.Changes = d.changes
+	result.Changed = len(d.changes) > 0
+	return result
+}
+
+type deploymentDiff struct {
+	changes []model.DeploymentChange
+}
+
+func (this *deploymentDiff) add(bpmnId string, field string, old interface{}, new interface{}) {
+	this.changes = append(this.changes, model.DeploymentChange{BpmnId: bpmnId, Field: field, Old: old, New: new})
+}
+
+func (this *deploymentDiff) compare(bpmnId string, field string, old interface{}, new interface{}) {
+	if !reflect.DeepEqual(old, new) {
+		this.add(bpmnId, field, old, new)
+	}
+}
+
+// expects old and new to be pointers; reports parts that exist in only one of them as change
+// returns true if both exist and their fields have to be compared
+func (this *deploymentDiff) compareParts(bpmnId string, field string, old interface{}, new interface{}) (bothExist bool) {
+	oldIsNil, newIsNil := reflect.ValueOf(old).IsNil(), reflect.ValueOf(new).IsNil()
+	switch {
+	case oldIsNil && newIsNil:
+		return false
+	case oldIsNil:
+		this.add(bpmnId, field, nil, new)
+		return false
+	case newIsNil:
+		this.add(bpmnId, field, old, nil)
+		return false
+	}
+	return true
+}
+
+func (this *deploymentDiff) compareElements(old deploymentmodel.Element, new deploymentmodel.Element) {
+	id := new.BpmnId
+	this.compare(id, "name", old.Name, new.Name)
+	this.compare(id, "group", stringValue(old.Group), stringValue(new.Group))
+	if this.compareParts(id, "task", old.Task, new.Task) {
+		this.compare(id, "task.retries", old.Task.Retries, new.Task.Retries)
+		this.compare(id, "task.prefer_event", old.Task.PreferEvent, new.Task.PreferEvent)
+		this.compareMaps(id, "task.parameter", old.Task.Parameter, new.Task.Parameter)
+		this.compareSelections(id, "task.selection", old.Task.Selection, new.Task.Selection)
+	}
+	if this.compareParts(id, "time_event", old.TimeEvent, new.TimeEvent) {
+		this.compare(id, "time_event.type", old.TimeEvent.Type, new.TimeEvent.Type)
+		this.compare(id, "time_event.time", old.TimeEvent.Time, new.TimeEvent.Time)
+	}
+	if this.compareParts(id, "conditional_event", old.ConditionalEvent, new.ConditionalEvent) {
+		this.compare(id, "conditional_event.script", old.ConditionalEvent.Script, new.ConditionalEvent.Script)
+		this.compare(id, "conditional_event.value_variable", old.ConditionalEvent.ValueVariable, new.ConditionalEvent.ValueVariable)
+		this.compare(id, "conditional_event.qos", old.ConditionalEvent.Qos, new.ConditionalEvent.Qos)
+		this.compareMaps(id, "conditional_event.variables", old.ConditionalEvent.Variables, new.ConditionalEvent.Variables)
+		this.compareSelections(id, "conditional_event.selection", old.ConditionalEvent.Selection, new.ConditionalEvent.Selection)
+	}
+	if this.compareParts(id, "message_event", old.MessageEvent, new.MessageEvent) {
+		this.compare(id, "message_event.value", old.MessageEvent.Value, new.MessageEvent.Value)
+		this.compare(id, "message_event.flow_id", old.MessageEvent.FlowId, new.MessageEvent.FlowId)
+		this.compareSelections(id, "message_event.selection", old.MessageEvent.Selection, new.MessageEvent.Selection)
+	}
+	if this.compareParts(id, "notification", old.Notification, new.Notification) {
+		this.compare(id, "notification.title", old.Notification.Title, new.Notification.Title)
+		this.compare(id, "notification.message", old.Notification.Message, new.Notification.Message)
+	}
+}
+
+// only the selected values are compared; the selection options depend on the hub
+func (this *deploymentDiff) compareSelections(bpmnId string, field string, old deploymentmodel.Selection, new deploymentmodel.Selection) {
+	this.compare(bpmnId, field+".selected_device_id", stringValue(old.SelectedDeviceId), stringValue(new.SelectedDeviceId))
+	this.compare(bpmnId, field+".selected_service_id", stringValue(old.SelectedServiceId), stringValue(new.SelectedServiceId))
+	this.compare(bpmnId, field+".selected_device_group_id", stringValue(old.SelectedDeviceGroupId), stringValue(new.SelectedDeviceGroupId))
+	this.compare(bpmnId, field+".selected_import_id", stringValue(old.SelectedImportId), stringValue(new.SelectedImportId))
+	var oldPath, newPath interface{}
+	if old.SelectedPath != nil {
+		oldPath = old.SelectedPath.Path
+	}
+	if new.SelectedPath != nil {
+		newPath = new.SelectedPath.Path
+	}
+	this.compare(bpmnId, field+".selected_path", oldPath, newPath)
+}
+
+func (this *deploymentDiff) compareMaps(bpmnId string, field string, old map[string]string, new map[string]string) {
+	keys := []string{}
+	for key := range old {
+		keys = append(keys, key)
+	}
+	for key := range new {
+		if _, ok := old[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var oldValue, newValue interface{}
+		if value, ok := old[key]; ok {
+			oldValue = value
+		}
+		if value, ok := new[key]; ok {
+			newValue = value
+		}
+		this.compare(bpmnId, field+"."+key, oldValue, newValue)
+	}
+}
+
+// returns nil for nil pointers, so that unset and empty values can be distinguished in the diff
+func stringValue(value *string) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
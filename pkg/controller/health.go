@@ -0,0 +1,93 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const readinessCheckTimeout = 2 * time.Second
+
+// CheckReadiness checks concurrently if the upstream services are reachable.
+// any http response below 500 counts as ready (e.g. 404 for the root path); further health checks of the upstream services are left to their own probes.
+func (this *Controller) CheckReadiness(ctx context.Context) (result model.ReadinessReport) {
+	dependencies := []struct {
+		name string
+		url  string
+	}{
+		{name: "device-repository", url: this.config.DeviceRepoUrl},
+		{name: "device-selection", url: this.config.DeviceSelectionUrl},
+		{name: "process-repository", url: this.config.ProcessRepoUrl},
+		{name: "permissions-v2", url: this.config.PermissionsV2Url},
+		{name: "process-sync", url: this.config.ProcessSyncUrl},
+	}
+	result.Dependencies = make([]model.DependencyStatus, len(dependencies))
+	wg := sync.WaitGroup{}
+	for i, dependency := range dependencies {
+		wg.Add(1)
+		go func(i int, name string, url string) {
+			defer wg.Done()
+			result.Dependencies[i] = checkDependency(ctx, name, url)
+		}(i, dependency.name, dependency.url)
+	}
+	wg.Wait()
+	result.Ready = true
+	for _, dependency := range result.Dependencies {
+		result.Ready = result.Ready && dependency.Ready
+	}
+	return result
+}
+
+func checkDependency(ctx context.Context, name string, url string) (result model.DependencyStatus) {
+	result.Name = name
+	if url == "" {
+		result.Error = "not configured"
+		return result
+	}
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if errors.Is(err, context.DeadlineExceeded) {
+		result.Error = "timeout after " + readinessCheckTimeout.String()
+		return result
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	resp.Body.Close()
+	result.Status = resp.StatusCode
+	if resp.StatusCode >= 500 {
+		result.Error = "unexpected status " + strconv.Itoa(resp.StatusCode)
+		return result
+	}
+	result.Ready = true
+	return result
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"net/http"
+)
+
+func (this *Controller) ListIncidents(ctx context.Context, token auth.Token, hubId string, deploymentId string) (result []model.FogIncident, err error, code int) {
+	definitionIds, err, code := this.getProcessDefinitionIds(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	incidents, err, code := this.processSync.Incidents(ctx, token.Jwt(), hubId)
+	if err != nil {
+		return result, err, code
+	}
+	result = []model.FogIncident{}
+	for _, incident := range incidents {
+		camundaDeploymentId, ok := definitionIds[incident.ProcessDefinitionId]
+		if !ok {
+			continue
+		}
+		result = append(result, model.FogIncident{
+			Id:                  incident.Id,
+			CamundaDeploymentId: camundaDeploymentId,
+			ProcessInstanceId:   incident.ProcessInstanceId,
+			ProcessDefinitionId: incident.ProcessDefinitionId,
+			BpmnId:              incident.ActivityId,
+			ErrorMessage:        incident.ErrorMessage,
+			Time:                incident.Time,
+			SyncInfo:            incident.SyncInfo,
+		})
+	}
+	return result, nil, http.StatusOK
+}
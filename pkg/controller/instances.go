@@ -0,0 +1,127 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"net/http"
+)
+
+func (this *Controller) ListProcessInstances(ctx context.Context, token auth.Token, hubId string, deploymentId string) (result []model.ProcessInstance, err error, code int) {
+	definitionIds, err, code := this.getProcessDefinitionIds(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	instances, err, code := this.processSync.ProcessInstances(ctx, token.Jwt(), hubId)
+	if err != nil {
+		return result, err, code
+	}
+	result = []model.ProcessInstance{}
+	for _, instance := range instances {
+		if _, ok := definitionIds[instance.DefinitionId]; ok {
+			result = append(result, instance)
+		}
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) ListHistoricProcessInstances(ctx context.Context, token auth.Token, hubId string, deploymentId string) (result []model.HistoricProcessInstance, err error, code int) {
+	definitionIds, err, code := this.getProcessDefinitionIds(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	instances, err, code := this.processSync.HistoricProcessInstances(ctx, token.Jwt(), hubId)
+	if err != nil {
+		return result, err, code
+	}
+	result = []model.HistoricProcessInstance{}
+	for _, instance := range instances {
+		if _, ok := definitionIds[instance.ProcessDefinitionId]; ok {
+			result = append(result, instance)
+		}
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) StopProcessInstance(ctx context.Context, token auth.Token, hubId string, deploymentId string, instanceId string) (err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Execute)
+	if err != nil {
+		return err, code
+	}
+	instances, err, code := this.ListProcessInstances(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return err, code
+	}
+	for _, instance := range instances {
+		if instance.Id == instanceId {
+			return this.processSync.StopProcessInstance(ctx, token.Jwt(), hubId, instanceId)
+		}
+	}
+	return errors.New("process instance not found"), http.StatusNotFound
+}
+
+func (this *Controller) DeleteHistoricProcessInstance(ctx context.Context, token auth.Token, hubId string, deploymentId string, instanceId string) (err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Administrate)
+	if err != nil {
+		return err, code
+	}
+	instances, err, code := this.ListHistoricProcessInstances(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return err, code
+	}
+	for _, instance := range instances {
+		if instance.Id == instanceId {
+			return this.processSync.DeleteHistoricProcessInstance(ctx, token.Jwt(), hubId, instanceId)
+		}
+	}
+	return errors.New("process instance not found"), http.StatusNotFound
+}
+
+// process instances reference process definitions, which reference the camunda deployments behind a fog deployment
+// returns the camunda deployment id indexed by process definition id
+func (this *Controller) getProcessDefinitionIds(ctx context.Context, token auth.Token, hubId string, deploymentId string) (result map[string]string, err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Read)
+	if err != nil {
+		return result, err, code
+	}
+	metadata, err, code := this.processSync.Metadata(ctx, token.Jwt(), hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	if len(metadata) == 0 {
+		return result, errors.New("deployment not found"), http.StatusNotFound
+	}
+	camundaDeploymentIds := map[string]bool{}
+	for _, m := range metadata {
+		camundaDeploymentIds[m.CamundaDeploymentId] = true
+	}
+	definitions, err, code := this.processSync.ProcessDefinitions(ctx, token.Jwt(), hubId)
+	if err != nil {
+		return result, err, code
+	}
+	result = map[string]string{}
+	for _, definition := range definitions {
+		if camundaDeploymentIds[definition.DeploymentId] {
+			result[definition.Id] = definition.DeploymentId
+		}
+	}
+	return result, nil, http.StatusOK
+}
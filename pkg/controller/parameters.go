@@ -0,0 +1,243 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GetStartParameterSchema describes the start parameters of a deployment as json schema.
+// parameters without default value are required.
+func (this *Controller) GetStartParameterSchema(ctx context.Context, token auth.Token, hubId string, deploymentId string) (result model.JsonSchema, err error, code int) {
+	parameters, err, code := this.getStartParameters(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	additionalProperties := false
+	result = model.JsonSchema{
+		Schema:               "https://json-schema.org/draft/2020-12/schema",
+		Type:                 "object",
+		Properties:           map[string]model.JsonSchema{},
+		Required:             []string{},
+		AdditionalProperties: &additionalProperties,
+	}
+	for name, parameter := range parameters {
+		property := model.JsonSchema{Title: name}
+		property.Type, property.Format = jsonSchemaType(parameter.Type)
+		if parameter.Value != nil {
+			property.Default = parameter.Value
+			if parameter.Type == "Json" {
+				//camunda transports json values as string
+				if str, ok := parameter.Value.(string); ok {
+					var temp interface{}
+					if json.Unmarshal([]byte(str), &temp) == nil {
+						property.Default = temp
+					}
+				}
+			}
+		} else {
+			result.Required = append(result.Required, name)
+		}
+		result.Properties[name] = property
+	}
+	sort.Strings(result.Required)
+	return result, nil, http.StatusOK
+}
+
+// returns the start parameters of all camunda deployments behind the fog deployment
+func (this *Controller) getStartParameters(ctx context.Context, token auth.Token, hubId string, deploymentId string) (result map[string]model.Variable, err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Read)
+	if err != nil {
+		return result, err, code
+	}
+	metadata, err, code := this.processSync.Metadata(ctx, token.Jwt(), hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	if len(metadata) == 0 {
+		return result, errors.New("deployment not found"), http.StatusNotFound
+	}
+	result = mergeStartParameters(metadata)
+	if result == nil {
+		result = map[string]model.Variable{}
+	}
+	return result, nil, http.StatusOK
+}
+
+// returns nil if no metadata declares start parameters (e.g. older process-sync versions)
+func mergeStartParameters(metadata []model.DeploymentMetadata) (result map[string]model.Variable) {
+	for _, m := range metadata {
+		if m.ProcessParameter == nil {
+			continue
+		}
+		if result == nil {
+			result = map[string]model.Variable{}
+		}
+		for name, parameter := range m.ProcessParameter {
+			if _, ok := result[name]; !ok {
+				result[name] = parameter
+			}
+		}
+	}
+	return result
+}
+
+// coerceStartInputs checks the inputs against the declared start parameters and converts them to the declared types.
+// unknown and missing parameters (parameters without default value) are rejected.
+func coerceStartInputs(parameters map[string]model.Variable, inputs map[string]interface{}) (result map[string]interface{}, err error) {
+	problems := []string{}
+	result = map[string]interface{}{}
+	for name, value := range inputs {
+		parameter, ok := parameters[name]
+		if !ok {
+			problems = append(problems, "unknown parameter '"+name+"'")
+			continue
+		}
+		result[name], err = coerceStartInput(parameter.Type, value)
+		if err != nil {
+			problems = append(problems, "parameter '"+name+"': "+err.Error())
+		}
+	}
+	for name, parameter := range parameters {
+		if _, ok := inputs[name]; !ok && parameter.Value == nil {
+			problems = append(problems, "missing parameter '"+name+"'")
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return result, &model.Error{
+			Code: model.ErrorCodeInvalidStartParameters,
+			Err:  errors.New("invalid start parameters: " + strings.Join(problems, "; ")),
+		}
+	}
+	return result, nil
+}
+
+// inputs may be decoded from json (json.Number, bool, string, map, slice) or from query parameters (float64, bool, string, ...)
+func coerceStartInput(camundaType string, value interface{}) (result interface{}, err error) {
+	switch camundaType {
+	case "String":
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case json.Number, float64, bool:
+			return fmt.Sprint(v), nil
+		}
+		return nil, errors.New("expected String")
+	case "Integer", "Long", "Short":
+		return coerceIntegerStartInput(camundaType, value)
+	case "Double":
+		switch v := value.(type) {
+		case json.Number:
+			return v.Float64()
+		case float64:
+			return v, nil
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+		return nil, errors.New("expected Double")
+	case "Boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+		return nil, errors.New("expected Boolean")
+	case "Json":
+		if str, ok := value.(string); ok {
+			err = json.Unmarshal([]byte(str), &result)
+			if err != nil {
+				return nil, errors.New("expected Json")
+			}
+			return result, nil
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+// integer inputs must fit into the java type of the camunda variable (Short: int16, Integer: int32, Long: int64)
+func coerceIntegerStartInput(camundaType string, value interface{}) (result int64, err error) {
+	bitSize := 64
+	switch camundaType {
+	case "Integer":
+		bitSize = 32
+	case "Short":
+		bitSize = 16
+	}
+	switch v := value.(type) {
+	case json.Number:
+		result, err = strconv.ParseInt(v.String(), 10, bitSize)
+	case string:
+		result, err = strconv.ParseInt(v, 10, bitSize)
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, errors.New("expected " + camundaType)
+		}
+		//float64(math.MaxInt64) is rounded up to 2^63
+		if v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, errors.New("out of range for " + camundaType)
+		}
+		result = int64(v)
+		limit := int64(1) << (bitSize - 1)
+		if bitSize < 64 && (result < -limit || result >= limit) {
+			return 0, errors.New("out of range for " + camundaType)
+		}
+		return result, nil
+	default:
+		return 0, errors.New("expected " + camundaType)
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, errors.New("out of range for " + camundaType)
+	}
+	if err != nil {
+		return 0, errors.New("expected " + camundaType)
+	}
+	return result, nil
+}
+
+// maps camunda variable types to json schema types
+func jsonSchemaType(camundaType string) (schemaType string, format string) {
+	switch camundaType {
+	case "String":
+		return "string", ""
+	case "Integer", "Long", "Short":
+		return "integer", ""
+	case "Double":
+		return "number", ""
+	case "Boolean":
+		return "boolean", ""
+	case "Date":
+		return "string", "date-time"
+	default:
+		//Json, Object and unknown types accept any value
+		return "", ""
+	}
+}
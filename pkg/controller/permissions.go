@@ -0,0 +1,51 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/metrics"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"net/http"
+	"time"
+)
+
+// permissions-v2 topic of the hubs
+const hubTopic = "hubs"
+
+const upstreamPermissions = "permissions-v2"
+
+// checks that the user of the token has the given permission on the hub.
+// responds with 403 if the permission is missing and with 502 if permissions-v2 could not answer the request.
+func (this *Controller) checkHubAccess(token string, hubId string, permission permv2.Permission) (err error, code int) {
+	start := time.Now()
+	access, err, code := this.permissions.CheckPermission(token, hubTopic, hubId, permission)
+	metrics.ObserveUpstreamCall(upstreamPermissions, "check-hub", start, code, err)
+	if err != nil {
+		result := &model.Error{Upstream: upstreamPermissions, Err: err}
+		if code == 0 || code >= 500 {
+			result.Code = model.ErrorCodeUpstream
+			code = http.StatusBadGateway
+		}
+		return result, code
+	}
+	if !access {
+		return &model.Error{Code: model.ErrorCodeForbidden, Err: errors.New("missing '" + string(permission) + "' permission for hub " + hubId)}, http.StatusForbidden
+	}
+	return nil, http.StatusOK
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tracing"
+	"github.com/SENERGY-Platform/service-commons/pkg/jwt"
+	"go.opentelemetry.io/otel/attribute"
+	"log"
+	"time"
+)
+
+// StartScheduler starts due schedules in the configured schedule_check_interval until ctx is done.
+// multiple service instances may run the scheduler; each run is claimed by exactly one instance.
+func (this *Controller) StartScheduler(ctx context.Context) {
+	if this.scheduleCheckInterval <= 0 {
+		log.Println("WARNING: scheduler disabled")
+		return
+	}
+	ticker := time.NewTicker(this.scheduleCheckInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				this.runDueSchedules(ctx)
+			}
+		}
+	}()
+}
+
+func (this *Controller) runDueSchedules(ctx context.Context) {
+	now := time.Now()
+	schedules, err := this.db.ListDueSchedules(now)
+	if err != nil {
+		log.Println("ERROR: unable to list due schedules", err)
+		return
+	}
+	for _, schedule := range schedules {
+		//runs missed while the service was down are started once
+		nextRun, err := nextScheduleRun(schedule, now)
+		if err != nil {
+			log.Println("ERROR: invalid schedule", schedule.Id, err)
+			continue
+		}
+		claimed, err := this.db.ClaimScheduleRun(schedule, nextRun, now.UTC())
+		if err != nil {
+			log.Println("ERROR: unable to claim schedule run", schedule.Id, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		lastError := ""
+		err = this.runSchedule(ctx, schedule)
+		if err != nil {
+			log.Println("ERROR: scheduled start failed", schedule.Id, err)
+			lastError = err.Error()
+		}
+		err = this.db.SetScheduleRunResult(schedule.Id, lastError)
+		if err != nil {
+			log.Println("ERROR: unable to store schedule run result", schedule.Id, err)
+		}
+	}
+}
+
+func (this *Controller) runSchedule(ctx context.Context, schedule model.Schedule) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "scheduled start", attribute.String("schedule.id", schedule.Id), attribute.String("hub.id", schedule.HubId), attribute.String("deployment.id", schedule.DeploymentId))
+	defer func() { tracing.EndSpan(span, err) }()
+	userToken, _, err := jwt.ExchangeUserToken(this.config.AuthEndpoint, this.config.AuthClientId, this.config.AuthClientSecret, schedule.UserId)
+	if err != nil {
+		return err
+	}
+	token, err := auth.Parse(userToken.Jwt())
+	if err != nil {
+		return err
+	}
+	_, err, _ = this.StartDeployment(ctx, token, schedule.HubId, schedule.DeploymentId, "", schedule.Inputs)
+	return err
+}
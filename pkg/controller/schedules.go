@@ -0,0 +1,160 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	"net/http"
+	"time"
+)
+
+const minScheduleInterval = time.Minute
+
+func (this *Controller) ListSchedules(token auth.Token, hubId string) (result []model.Schedule, err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Read)
+	if err != nil {
+		return result, err, code
+	}
+	result, err = this.db.ListSchedules(token.GetUserId(), hubId)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) GetSchedule(token auth.Token, hubId string, id string) (result model.Schedule, err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Read)
+	if err != nil {
+		return result, err, code
+	}
+	result, exists, err := this.db.GetSchedule(id)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !exists || result.UserId != token.GetUserId() || result.HubId != hubId {
+		return model.Schedule{}, errors.New("schedule not found"), http.StatusNotFound
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) CreateSchedule(ctx context.Context, token auth.Token, hubId string, schedule model.Schedule) (result model.Schedule, err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Execute)
+	if err != nil {
+		return result, err, code
+	}
+	schedule.Id = uuid.NewString()
+	schedule.UserId = token.GetUserId()
+	schedule.HubId = hubId
+	schedule.LastRun = nil
+	schedule.LastError = ""
+	return this.setSchedule(ctx, token, schedule)
+}
+
+func (this *Controller) UpdateSchedule(ctx context.Context, token auth.Token, hubId string, id string, schedule model.Schedule) (result model.Schedule, err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Execute)
+	if err != nil {
+		return result, err, code
+	}
+	if schedule.Id != "" && schedule.Id != id {
+		return result, errors.New("schedule id in body does not match id in path"), http.StatusBadRequest
+	}
+	existing, err, code := this.GetSchedule(token, hubId, id)
+	if err != nil {
+		return result, err, code
+	}
+	schedule.Id = existing.Id
+	schedule.UserId = existing.UserId
+	schedule.HubId = existing.HubId
+	schedule.LastRun = existing.LastRun
+	schedule.LastError = existing.LastError
+	return this.setSchedule(ctx, token, schedule)
+}
+
+func (this *Controller) DeleteSchedule(token auth.Token, hubId string, id string) (err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Execute)
+	if err != nil {
+		return err, code
+	}
+	_, err, code = this.GetSchedule(token, hubId, id)
+	if err != nil {
+		return err, code
+	}
+	err = this.db.RemoveSchedule(id)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+func (this *Controller) setSchedule(ctx context.Context, token auth.Token, schedule model.Schedule) (result model.Schedule, err error, code int) {
+	if schedule.DeploymentId == "" {
+		return result, errors.New("missing deployment_id"), http.StatusBadRequest
+	}
+	schedule.NextRun, err = nextScheduleRun(schedule, time.Now())
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	metadata, err, code := this.processSync.Metadata(ctx, token.Jwt(), schedule.HubId, schedule.DeploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	if len(metadata) == 0 {
+		return result, errors.New("unknown deployment"), http.StatusBadRequest
+	}
+	if parameters := mergeStartParameters(metadata); parameters != nil {
+		_, err = coerceStartInputs(parameters, schedule.Inputs)
+		if err != nil {
+			return result, err, http.StatusBadRequest
+		}
+	}
+	err = this.db.SetSchedule(schedule)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return schedule, nil, http.StatusOK
+}
+
+// returns the first run of the schedule after the given time
+func nextScheduleRun(schedule model.Schedule, after time.Time) (result time.Time, err error) {
+	switch {
+	case schedule.Cron != "" && schedule.Interval != "":
+		return result, errors.New("expect either cron or interval, not both")
+	case schedule.Cron != "":
+		expression, err := cron.ParseStandard(schedule.Cron)
+		if err != nil {
+			return result, err
+		}
+		return expression.Next(after).UTC(), nil
+	case schedule.Interval != "":
+		interval, err := time.ParseDuration(schedule.Interval)
+		if err != nil {
+			return result, err
+		}
+		if interval < minScheduleInterval {
+			return result, errors.New("interval must be at least " + minScheduleInterval.String())
+		}
+		return after.Add(interval).Truncate(time.Second).UTC(), nil
+	default:
+		return result, errors.New("expect cron or interval")
+	}
+}
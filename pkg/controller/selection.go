@@ -0,0 +1,162 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"strconv"
+)
+
+// returns the selections of all tasks and events, indexed by bpmn id
+func getSelections(deployment deploymentmodel.Deployment) (result map[string]deploymentmodel.Selection) {
+	result = map[string]deploymentmodel.Selection{}
+	for _, element := range deployment.Elements {
+		if element.Task != nil {
+			result[element.BpmnId] = element.Task.Selection
+		}
+		if element.ConditionalEvent != nil {
+			result[element.BpmnId] = element.ConditionalEvent.Selection
+		}
+	}
+	return result
+}
+
+// resolveSelections selects for every task and event one of the current SelectionOptions,
+// preferring the option that matches the previous selection of the element.
+// expects the SelectionOptions to be set for the hub the deployment will be deployed to (see SetDeploymentOptions).
+func resolveSelections(deployment *deploymentmodel.Deployment, previous map[string]deploymentmodel.Selection) (unmatched []model.UnmatchedSelection) {
+	unmatched = []model.UnmatchedSelection{}
+	for i, element := range deployment.Elements {
+		var selection *deploymentmodel.Selection
+		if element.Task != nil {
+			selection = &element.Task.Selection
+		}
+		if element.ConditionalEvent != nil {
+			selection = &element.ConditionalEvent.Selection
+		}
+		if selection == nil {
+			continue
+		}
+		reason := resolveSelection(selection, previous[element.BpmnId])
+		if reason != "" {
+			unmatched = append(unmatched, model.UnmatchedSelection{
+				BpmnId: element.BpmnId,
+				Name:   element.Name,
+				Reason: reason,
+			})
+		}
+		deployment.Elements[i] = element
+	}
+	return unmatched
+}
+
+// returns an empty string on success, else the reason why no option could be selected
+func resolveSelection(selection *deploymentmodel.Selection, previous deploymentmodel.Selection) (reason string) {
+	selection.SelectedDeviceId = nil
+	selection.SelectedServiceId = nil
+	selection.SelectedDeviceGroupId = nil
+	selection.SelectedPath = nil
+	if len(selection.SelectionOptions) == 0 {
+		return "no matching device on target hub"
+	}
+	if previous.SelectedDeviceGroupId != nil {
+		for _, option := range selection.SelectionOptions {
+			if option.DeviceGroup != nil && option.DeviceGroup.Id == *previous.SelectedDeviceGroupId {
+				selection.SelectedDeviceGroupId = &option.DeviceGroup.Id
+				return ""
+			}
+		}
+		return "selected device-group is not available on target hub"
+	}
+
+	previousDeviceName := ""
+	previousServiceName := ""
+	for _, option := range previous.SelectionOptions {
+		if option.Device != nil && previous.SelectedDeviceId != nil && option.Device.Id == *previous.SelectedDeviceId {
+			previousDeviceName = option.Device.Name
+			for _, service := range option.Services {
+				if previous.SelectedServiceId != nil && service.Id == *previous.SelectedServiceId {
+					previousServiceName = service.Name
+				}
+			}
+		}
+	}
+
+	deviceOptions := []deploymentmodel.SelectionOption{}
+	for _, option := range selection.SelectionOptions {
+		if option.Device != nil {
+			deviceOptions = append(deviceOptions, option)
+		}
+	}
+	var match *deploymentmodel.SelectionOption
+	for _, option := range deviceOptions {
+		if previous.SelectedDeviceId != nil && option.Device.Id == *previous.SelectedDeviceId {
+			match = &option
+			break
+		}
+	}
+	if match == nil && previousDeviceName != "" {
+		for _, option := range deviceOptions {
+			if option.Device.Name == previousDeviceName {
+				match = &option
+				break
+			}
+		}
+	}
+	if match == nil && len(deviceOptions) == 1 {
+		match = &deviceOptions[0]
+	}
+	if match == nil {
+		return "ambiguous selection: " + strconv.Itoa(len(deviceOptions)) + " matching devices on target hub"
+	}
+
+	var service *deploymentmodel.Service
+	for _, s := range match.Services {
+		if (previous.SelectedServiceId != nil && s.Id == *previous.SelectedServiceId) || (previousServiceName != "" && s.Name == previousServiceName) {
+			service = &s
+			break
+		}
+	}
+	if service == nil && len(match.Services) == 1 {
+		service = &match.Services[0]
+	}
+	if service == nil && previous.SelectedServiceId != nil {
+		return "ambiguous selection: " + strconv.Itoa(len(match.Services)) + " matching services of device " + match.Device.Name
+	}
+	selection.SelectedDeviceId = &match.Device.Id
+	if service == nil {
+		return ""
+	}
+	selection.SelectedServiceId = &service.Id
+
+	pathOptions := match.PathOptions[service.Id]
+	if previous.SelectedPath == nil || len(pathOptions) == 0 {
+		return ""
+	}
+	for _, path := range pathOptions {
+		if path.Path == previous.SelectedPath.Path {
+			selection.SelectedPath = &path
+			return ""
+		}
+	}
+	if len(pathOptions) == 1 {
+		selection.SelectedPath = &pathOptions[0]
+		return ""
+	}
+	return "ambiguous selection: " + strconv.Itoa(len(pathOptions)) + " matching paths of service " + service.Name
+}
@@ -26,9 +26,11 @@ import (
 
 // mocks sourcing interface to reuse github.com/SENERGY-Platform/process-deployment/lib/ctrl without connecting to kafka
 type SourcingReplacement struct {
-	token       string
-	hubId       string
-	processSync ProcessSync
+	token        string
+	hubId        string
+	deploymentId string
+	dryRun       bool
+	processSync  ProcessSync
 }
 
 func (this *SourcingReplacement) NewConsumer(ctx context.Context, config config.Config, topic string, listener func(delivery []byte) error) error {
@@ -37,9 +39,12 @@ func (this *SourcingReplacement) NewConsumer(ctx context.Context, config config.
 
 // reroutes deployment requests to github.com/SENERGY-Platform/process-sync
 type ProducerReplacement struct {
-	token       string
-	hubId       string
-	processSync ProcessSync
+	ctx          context.Context //context of the pipeline run (see ctrl.New)
+	token        string
+	hubId        string
+	deploymentId string //if set, replaces the id generated by ctrl.CreateDeployment (used to update existing deployments)
+	dryRun       bool   //if set, the deployment is only validated and not sent to process-sync
+	processSync  ProcessSync
 }
 
 func (this *ProducerReplacement) Produce(topic string, message []byte) error {
@@ -52,13 +57,22 @@ func (this *ProducerReplacement) Produce(topic string, message []byte) error {
 	if err = validateDeployment(deplMsg); err != nil {
 		return err
 	}
-	return this.processSync.Deploy(this.token, this.hubId, *deplMsg.Deployment)
+	if this.dryRun {
+		return nil
+	}
+	if this.deploymentId != "" {
+		deplMsg.Deployment.Id = this.deploymentId
+	}
+	return this.processSync.Deploy(this.ctx, this.token, this.hubId, *deplMsg.Deployment)
 }
 
 func (this *SourcingReplacement) NewProducer(ctx context.Context, config config.Config, topic string) (interfaces.Producer, error) {
 	return &ProducerReplacement{
-		token:       this.token,
-		hubId:       this.hubId,
-		processSync: this.processSync,
+		ctx:          ctx,
+		token:        this.token,
+		hubId:        this.hubId,
+		deploymentId: this.deploymentId,
+		dryRun:       this.dryRun,
+		processSync:  this.processSync,
 	}, nil
 }
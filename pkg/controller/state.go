@@ -0,0 +1,52 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"time"
+)
+
+func (this *Controller) GetDeploymentState(info model.SyncInfo) model.DeploymentState {
+	pending := info.IsPlaceholder || info.MarkedForDelete
+	if pending && this.staleDuration > 0 && time.Since(info.SyncDate) > this.staleDuration {
+		return model.DeploymentStateStale
+	}
+	if info.MarkedForDelete {
+		return model.DeploymentStatePendingDelete
+	}
+	if info.IsPlaceholder {
+		return model.DeploymentStatePendingSync
+	}
+	return model.DeploymentStateActive
+}
+
+// combines the states of multiple camunda deployments behind one fog deployment
+// the result is the most severe state: stale > pending-delete > pending-sync > active
+func combineDeploymentStates(a model.DeploymentState, b model.DeploymentState) model.DeploymentState {
+	if deploymentStateSeverity[a] >= deploymentStateSeverity[b] {
+		return a
+	}
+	return b
+}
+
+var deploymentStateSeverity = map[model.DeploymentState]int{
+	model.DeploymentStateActive:        1,
+	model.DeploymentStatePendingSync:   2,
+	model.DeploymentStatePendingDelete: 3,
+	model.DeploymentStateStale:         4,
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (this *Controller) ListTemplates(token auth.Token) (result []model.DeploymentTemplate, err error, code int) {
+	result, err = this.db.ListTemplates(token.GetUserId())
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) GetTemplate(token auth.Token, id string) (result model.DeploymentTemplate, err error, code int) {
+	result, exists, err := this.db.GetTemplate(id)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !exists || result.UserId != token.GetUserId() {
+		return model.DeploymentTemplate{}, errors.New("template not found"), http.StatusNotFound
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) CreateTemplate(token auth.Token, template model.DeploymentTemplate) (result model.DeploymentTemplate, err error, code int) {
+	template.Id = uuid.NewString()
+	template.UserId = token.GetUserId()
+	return this.setTemplate(template)
+}
+
+func (this *Controller) UpdateTemplate(token auth.Token, id string, template model.DeploymentTemplate) (result model.DeploymentTemplate, err error, code int) {
+	if template.Id != "" && template.Id != id {
+		return result, errors.New("template id in body does not match id in path"), http.StatusBadRequest
+	}
+	existing, err, code := this.GetTemplate(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	template.Id = existing.Id
+	template.UserId = existing.UserId
+	return this.setTemplate(template)
+}
+
+func (this *Controller) DeleteTemplate(token auth.Token, id string) (err error, code int) {
+	_, err, code = this.GetTemplate(token, id)
+	if err != nil {
+		return err, code
+	}
+	err = this.db.RemoveTemplate(id)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+func (this *Controller) setTemplate(template model.DeploymentTemplate) (result model.DeploymentTemplate, err error, code int) {
+	if template.Deployment.Diagram.XmlRaw == "" {
+		return result, errors.New("missing deployment diagram"), http.StatusBadRequest
+	}
+	if template.Name == "" {
+		template.Name = template.Deployment.Name
+	}
+	template.Date = time.Now().UTC()
+	template.Deployment.Id = ""
+	clearSelections(&template.Deployment)
+	err = this.db.SetTemplate(template)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return template, nil, http.StatusOK
+}
+
+// ApplyTemplate deploys the template to the hub.
+// the filter criteria of every task and event are resolved against the devices of the hub;
+// if not every selection can be resolved unambiguously, nothing is deployed and the unmatched tasks and events are reported.
+func (this *Controller) ApplyTemplate(ctx context.Context, token auth.Token, id string, hubId string, source string, optionals map[string]bool) (result model.DeploymentTransferResult, err error, code int) {
+	err, code = this.checkHubAccess(token.Jwt(), hubId, permv2.Execute)
+	if err != nil {
+		return result, err, code
+	}
+	template, err, code := this.GetTemplate(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	deviceRepo := this.deviceRepoFactory(ctx, this.config, this.reusedDeviceRepo, hubId)
+	hubRepo, ok := deviceRepo.(HubRepo)
+	if !ok {
+		return result, errors.New("device repository is unable to read hubs"), http.StatusInternalServerError
+	}
+	hub, err, code := hubRepo.GetHub(token.Jwt(), hubId)
+	if err != nil {
+		return result, err, code
+	}
+	deployment := template.Deployment
+	placeholders := strings.NewReplacer("{{hub_id}}", hub.Id, "{{hub_name}}", hub.Name)
+	deployment.Name = placeholders.Replace(deployment.Name)
+	deployment.Description = placeholders.Replace(deployment.Description)
+
+	err = this.ReuseCloudDeploymentWithNewDeviceRepo(ctx, hubId).SetDeploymentOptions(token, &deployment)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	this.SetExecutableFlag(&deployment)
+	return this.deployWithResolvedSelections(ctx, token, hubId, deployment, map[string]deploymentmodel.Selection{}, source, optionals)
+}
+
+// removes everything hub specific from the selections, leaving only the filter criteria
+func clearSelections(deployment *deploymentmodel.Deployment) {
+	for i, element := range deployment.Elements {
+		var selection *deploymentmodel.Selection
+		if element.Task != nil {
+			selection = &element.Task.Selection
+		}
+		if element.MessageEvent != nil {
+			selection = &element.MessageEvent.Selection
+		}
+		if element.ConditionalEvent != nil {
+			selection = &element.ConditionalEvent.Selection
+		}
+		if selection == nil {
+			continue
+		}
+		*selection = deploymentmodel.Selection{FilterCriteria: selection.FilterCriteria}
+		deployment.Elements[i] = element
+	}
+}
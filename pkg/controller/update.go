@@ -0,0 +1,160 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tracing"
+	jsonpatch "github.com/evanphx/json-patch"
+	"go.opentelemetry.io/otel/attribute"
+	"log"
+	"net/http"
+)
+
+// UpdateDeployment redeploys the given deployment under the existing deploymentId.
+// the old camunda deployments are only removed after the new one has been deployed.
+// removing them requires the same permission as RemoveDeployment.
+func (this *Controller) UpdateDeployment(ctx context.Context, token string, hubId string, deploymentId string, deployment deploymentmodel.Deployment, source string, optionals map[string]bool) (result model.DeploymentWithState, err error, code int) {
+	ctx, span := tracing.StartSpan(ctx, "update deployment", attribute.String("hub.id", hubId), attribute.String("deployment.id", deploymentId))
+	defer func() { tracing.EndSpan(span, err) }()
+	err, code = this.checkHubAccess(token, hubId, permv2.Administrate)
+	if err != nil {
+		return result, err, code
+	}
+	if deployment.Id != "" && deployment.Id != deploymentId {
+		return result, errors.New("path id != body id"), http.StatusBadRequest
+	}
+	jwtToken, err := auth.Parse(token)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	old, err, code := this.processSync.Metadata(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	if len(old) == 0 {
+		return result, errors.New("deployment not found"), http.StatusNotFound
+	}
+	//deployments created before versions were recorded get their current model as first version
+	versions, err := this.db.ListDeploymentVersions(hubId, deploymentId)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if len(versions) == 0 {
+		this.recordDeploymentVersion(jwtToken.GetUserId(), hubId, old[0].DeploymentModel)
+	}
+	result.Deployment, err, code = this.ReuseCloudDeploymentWithProcessSyncForId(ctx, token, hubId, deploymentId).
+		CreateDeployment(
+			jwtToken,
+			deployment,
+			source,
+			optionals)
+	if err != nil {
+		return result, err, code
+	}
+	result.Id = deploymentId
+	removed := []model.DeploymentMetadata{}
+	for _, m := range old {
+		err, code = this.processSync.Remove(ctx, token, hubId, m.CamundaDeploymentId)
+		if err != nil {
+			rollbackErr := this.rollbackUpdate(ctx, token, hubId, deploymentId, old, removed)
+			if rollbackErr != nil {
+				//the hub may have both or none of the versions deployed
+				return result, &model.Error{Code: model.ErrorCodeRollbackFailed, Err: fmt.Errorf("%w; rollback failed: %w", err, rollbackErr)}, code
+			}
+			return result, err, code
+		}
+		removed = append(removed, m)
+	}
+	this.recordDeploymentVersion(jwtToken.GetUserId(), hubId, result.Deployment)
+	result.State = model.DeploymentStatePendingSync
+	return result, nil, http.StatusOK
+}
+
+// PatchDeployment applies a json-patch (RFC 6902) to the currently deployed model and updates the deployment with the result
+func (this *Controller) PatchDeployment(ctx context.Context, token string, hubId string, deploymentId string, patch []byte, source string, optionals map[string]bool) (result model.DeploymentWithState, err error, code int) {
+	//checked before the current model is loaded, see UpdateDeployment
+	err, code = this.checkHubAccess(token, hubId, permv2.Administrate)
+	if err != nil {
+		return result, err, code
+	}
+	jwtToken, err := auth.Parse(token)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	current, err, code := this.GetDeployment(ctx, jwtToken, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	decodedPatch, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	original, err := json.Marshal(current[0].Deployment)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	modified, err := decodedPatch.Apply(original)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	deployment := deploymentmodel.Deployment{}
+	err = json.Unmarshal(modified, &deployment)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	return this.UpdateDeployment(ctx, token, hubId, deploymentId, deployment, source, optionals)
+}
+
+// rollbackUpdate removes camunda deployments that were created by the failed update and redeploys already removed old ones.
+// returns the errors of every failed step.
+func (this *Controller) rollbackUpdate(ctx context.Context, token string, hubId string, deploymentId string, old []model.DeploymentMetadata, removed []model.DeploymentMetadata) error {
+	isOld := map[string]bool{}
+	for _, m := range old {
+		isOld[m.CamundaDeploymentId] = true
+	}
+	errs := []error{}
+	current, err, _ := this.processSync.Metadata(ctx, token, hubId, deploymentId)
+	if err != nil {
+		log.Println("ERROR: unable to rollback update of", hubId, deploymentId, err)
+		errs = append(errs, fmt.Errorf("unable to load current camunda deployments: %w", err))
+	}
+	for _, m := range current {
+		if !isOld[m.CamundaDeploymentId] {
+			err, _ = this.processSync.Remove(ctx, token, hubId, m.CamundaDeploymentId)
+			if err != nil {
+				log.Println("ERROR: unable to remove new camunda deployment in rollback of", hubId, deploymentId, m.CamundaDeploymentId, err)
+				errs = append(errs, fmt.Errorf("unable to remove new camunda deployment %v: %w", m.CamundaDeploymentId, err))
+			}
+		}
+	}
+	for _, m := range removed {
+		err = this.processSync.Deploy(ctx, token, hubId, m.DeploymentModel)
+		if err != nil {
+			log.Println("ERROR: unable to redeploy old camunda deployment in rollback of", hubId, deploymentId, m.CamundaDeploymentId, err)
+			errs = append(errs, fmt.Errorf("unable to redeploy old camunda deployment %v: %w", m.CamundaDeploymentId, err))
+		}
+	}
+	return errors.Join(errs...)
+}
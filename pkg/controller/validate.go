@@ -0,0 +1,79 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"net/http"
+)
+
+// ValidateDeployment runs the checks of CreateDeployment without deploying the result to the hub.
+// problems of single elements are collected for every element; the remaining pipeline (access checks, xml generation)
+// is only run if no such problem was found, because it stops at the first error.
+func (this *Controller) ValidateDeployment(ctx context.Context, token string, hubId string, deployment deploymentmodel.Deployment, source string, optionals map[string]bool) (result model.ValidationResult, err error, code int) {
+	err, code = this.checkHubAccess(token, hubId, permv2.Execute)
+	if err != nil {
+		return result, err, code
+	}
+	jwtToken, err := auth.Parse(token)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	result.Problems = []model.ValidationProblem{}
+
+	//the deployment id is set by the pipeline; elements are checked individually below
+	general := deployment
+	general.Id = "validation"
+	general.Elements = nil
+	err = general.Validate(deploymentmodel.ValidateRequest, optionals)
+	if err != nil {
+		result.Problems = append(result.Problems, model.ValidationProblem{Message: err.Error()})
+	}
+	for _, element := range deployment.Elements {
+		err = element.Validate(deploymentmodel.ValidateRequest, optionals)
+		if err == nil {
+			err = validateElement(element)
+		}
+		if err != nil {
+			problem := model.ValidationProblem{BpmnId: element.BpmnId, Message: err.Error()}
+			var details *model.Error
+			if errors.As(err, &details) {
+				problem.Code = details.Code
+			}
+			result.Problems = append(result.Problems, problem)
+		}
+	}
+	if len(result.Problems) > 0 {
+		return result, nil, http.StatusOK
+	}
+
+	//the pipeline does not distinguish between invalid deployments and unavailable services (both may result in status 500)
+	//so every error is reported as problem
+	validated, err, _ := this.ReuseCloudDeploymentForValidation(ctx, token, hubId).CreateDeployment(jwtToken, deployment, source, optionals)
+	if err != nil {
+		result.Problems = append(result.Problems, model.ValidationProblem{Message: err.Error()})
+		return result, nil, http.StatusOK
+	}
+	result.Valid = true
+	result.Deployment = &validated
+	return result, nil, http.StatusOK
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
 	"github.com/SENERGY-Platform/process-deployment/lib/model/messages"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
 )
 
 func validateDeployment(msg messages.DeploymentCommand) error {
@@ -30,11 +31,26 @@ func validateDeployment(msg messages.DeploymentCommand) error {
 		return errors.New("unexpected deployment version")
 	}
 	for _, element := range msg.Deployment.Elements {
-		if element.MessageEvent != nil {
-			return errors.New("fog process deployments dont support message events. please use conditional events")
+		if err := validateElement(element); err != nil {
+			return err
 		}
-		if element.ConditionalEvent != nil && element.ConditionalEvent.Selection.SelectedImportId != nil {
-			return errors.New("fog process deployments dont support imports as selection for conditional events")
+	}
+	return nil
+}
+
+func validateElement(element deploymentmodel.Element) error {
+	if element.MessageEvent != nil {
+		return &model.Error{
+			Code:   model.ErrorCodeMessageEventsUnsupported,
+			BpmnId: element.BpmnId,
+			Err:    errors.New("fog process deployments dont support message events. please use conditional events"),
+		}
+	}
+	if element.ConditionalEvent != nil && element.ConditionalEvent.Selection.SelectedImportId != nil {
+		return &model.Error{
+			Code:   model.ErrorCodeImportSelectionUnsupported,
+			BpmnId: element.BpmnId,
+			Err:    errors.New("fog process deployments dont support imports as selection for conditional events"),
 		}
 	}
 	return nil
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/process-deployment/lib/auth"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"log"
+	"net/http"
+	"time"
+)
+
+func (this *Controller) ListDeploymentVersions(ctx context.Context, token auth.Token, hubId string, deploymentId string) (result []model.DeploymentVersion, err error, code int) {
+	_, err, code = this.GetDeployment(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	result, err = this.db.ListDeploymentVersions(hubId, deploymentId)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) GetDeploymentVersion(ctx context.Context, token auth.Token, hubId string, deploymentId string, version int) (result model.DeploymentVersion, err error, code int) {
+	_, err, code = this.GetDeployment(ctx, token, hubId, deploymentId)
+	if err != nil {
+		return result, err, code
+	}
+	result, exists, err := this.db.GetDeploymentVersion(hubId, deploymentId, version)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !exists {
+		return result, errors.New("version not found"), http.StatusNotFound
+	}
+	return result, nil, http.StatusOK
+}
+
+// RollbackDeployment redeploys the given version; the result is recorded as new version
+func (this *Controller) RollbackDeployment(ctx context.Context, token auth.Token, hubId string, deploymentId string, version int, source string, optionals map[string]bool) (result model.DeploymentWithState, err error, code int) {
+	target, err, code := this.GetDeploymentVersion(ctx, token, hubId, deploymentId, version)
+	if err != nil {
+		return result, err, code
+	}
+	return this.UpdateDeployment(ctx, token.Jwt(), hubId, deploymentId, target.Deployment, source, optionals)
+}
+
+// the deployment is already deployed, so a failure to record the version is only logged
+func (this *Controller) recordDeploymentVersion(userId string, hubId string, deployment deploymentmodel.Deployment) {
+	_, err := this.db.AddDeploymentVersion(model.DeploymentVersion{
+		HubId:        hubId,
+		DeploymentId: deployment.Id,
+		UserId:       userId,
+		Date:         time.Now().UTC(),
+		Deployment:   deployment,
+	})
+	if err != nil {
+		log.Println("ERROR: unable to record deployment version", hubId, deployment.Id, err)
+	}
+}
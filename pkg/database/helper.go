@@ -0,0 +1,71 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"reflect"
+	"time"
+)
+
+func getTimeoutContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+func getBsonFieldName(obj interface{}, fieldName string) (bsonName string, err error) {
+	field, found := reflect.TypeOf(obj).FieldByName(fieldName)
+	if !found {
+		return "", errors.New("field '" + fieldName + "' not found")
+	}
+	tags, err := bsoncodec.DefaultStructTagParser.ParseStructTags(field)
+	return tags.Name, err
+}
+
+func (this *Mongo) ensureCompoundIndex(collection *mongo.Collection, indexname string, asc bool, unique bool, indexKeys ...string) error {
+	ctx, _ := getTimeoutContext()
+	var direction int32 = -1
+	if asc {
+		direction = 1
+	}
+	keys := []bson.E{}
+	for _, key := range indexKeys {
+		keys = append(keys, bson.E{Key: key, Value: direction})
+	}
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D(keys),
+		Options: options.Index().SetName(indexname).SetUnique(unique),
+	})
+	return err
+}
+
+func (this *Mongo) ensureIndex(collection *mongo.Collection, indexname string, indexKey string, asc bool, unique bool) error {
+	ctx, _ := getTimeoutContext()
+	var direction int32 = -1
+	if asc {
+		direction = 1
+	}
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: indexKey, Value: direction}},
+		Options: options.Index().SetName(indexname).SetUnique(unique),
+	})
+	return err
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database
+
+import (
+	"errors"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"runtime/debug"
+	"time"
+)
+
+var scheduleIdKey string
+var scheduleUserIdKey string
+var scheduleHubIdKey string
+var scheduleDisabledKey string
+var scheduleNextRunKey string
+var scheduleLastRunKey string
+var scheduleLastErrorKey string
+
+func init() {
+	CreateCollections = append(CreateCollections, func(db *Mongo, config configuration.Config) error {
+		var err error
+		for fieldName, key := range map[string]*string{
+			"Id":        &scheduleIdKey,
+			"UserId":    &scheduleUserIdKey,
+			"HubId":     &scheduleHubIdKey,
+			"Disabled":  &scheduleDisabledKey,
+			"NextRun":   &scheduleNextRunKey,
+			"LastRun":   &scheduleLastRunKey,
+			"LastError": &scheduleLastErrorKey,
+		} {
+			*key, err = getBsonFieldName(model.Schedule{}, fieldName)
+			if err != nil {
+				debug.PrintStack()
+				return err
+			}
+		}
+		collection := db.schedulesCollection()
+		err = db.ensureIndex(collection, "scheduleidindex", scheduleIdKey, true, true)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "scheduleuseridindex", scheduleUserIdKey, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "schedulenextrunindex", scheduleNextRunKey, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) schedulesCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoScheduleCollection)
+}
+
+func (this *Mongo) ListSchedules(userId string, hubId string) (result []model.Schedule, err error) {
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.schedulesCollection().Find(ctx, bson.M{scheduleUserIdKey: userId, scheduleHubIdKey: hubId}, options.Find().SetSort(bson.D{{Key: scheduleIdKey, Value: 1}}))
+	if err != nil {
+		return result, err
+	}
+	result = []model.Schedule{}
+	err = cursor.All(ctx, &result)
+	return result, err
+}
+
+func (this *Mongo) GetSchedule(id string) (result model.Schedule, exists bool, err error) {
+	ctx, _ := getTimeoutContext()
+	err = this.schedulesCollection().FindOne(ctx, bson.M{scheduleIdKey: id}).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return result, false, nil
+	}
+	if err != nil {
+		return result, false, err
+	}
+	return result, true, nil
+}
+
+func (this *Mongo) SetSchedule(schedule model.Schedule) error {
+	ctx, _ := getTimeoutContext()
+	_, err := this.schedulesCollection().ReplaceOne(ctx, bson.M{scheduleIdKey: schedule.Id}, schedule, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (this *Mongo) RemoveSchedule(id string) error {
+	ctx, _ := getTimeoutContext()
+	_, err := this.schedulesCollection().DeleteOne(ctx, bson.M{scheduleIdKey: id})
+	return err
+}
+
+func (this *Mongo) ListDueSchedules(now time.Time) (result []model.Schedule, err error) {
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.schedulesCollection().Find(ctx, bson.M{scheduleDisabledKey: false, scheduleNextRunKey: bson.M{"$lte": now}})
+	if err != nil {
+		return result, err
+	}
+	result = []model.Schedule{}
+	err = cursor.All(ctx, &result)
+	return result, err
+}
+
+// ClaimScheduleRun moves the next run of the schedule, if no other instance has done so since the schedule was read.
+func (this *Mongo) ClaimScheduleRun(schedule model.Schedule, nextRun time.Time, lastRun time.Time) (claimed bool, err error) {
+	ctx, _ := getTimeoutContext()
+	result, err := this.schedulesCollection().UpdateOne(ctx,
+		bson.M{scheduleIdKey: schedule.Id, scheduleNextRunKey: schedule.NextRun},
+		bson.M{"$set": bson.M{scheduleNextRunKey: nextRun, scheduleLastRunKey: lastRun}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (this *Mongo) SetScheduleRunResult(id string, lastError string) error {
+	ctx, _ := getTimeoutContext()
+	_, err := this.schedulesCollection().UpdateOne(ctx, bson.M{scheduleIdKey: id}, bson.M{"$set": bson.M{scheduleLastErrorKey: lastError}})
+	return err
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database
+
+import (
+	"errors"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"runtime/debug"
+)
+
+var templateIdKey string
+var templateUserIdKey string
+var templateNameKey string
+
+func init() {
+	CreateCollections = append(CreateCollections, func(db *Mongo, config configuration.Config) error {
+		var err error
+		for fieldName, key := range map[string]*string{
+			"Id":     &templateIdKey,
+			"UserId": &templateUserIdKey,
+			"Name":   &templateNameKey,
+		} {
+			*key, err = getBsonFieldName(model.DeploymentTemplate{}, fieldName)
+			if err != nil {
+				debug.PrintStack()
+				return err
+			}
+		}
+		collection := db.templatesCollection()
+		err = db.ensureIndex(collection, "templateidindex", templateIdKey, true, true)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "templateuseridindex", templateUserIdKey, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) templatesCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoTemplateCollection)
+}
+
+func (this *Mongo) ListTemplates(userId string) (result []model.DeploymentTemplate, err error) {
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.templatesCollection().Find(ctx, bson.M{templateUserIdKey: userId}, options.Find().SetSort(bson.D{{Key: templateNameKey, Value: 1}, {Key: templateIdKey, Value: 1}}))
+	if err != nil {
+		return result, err
+	}
+	result = []model.DeploymentTemplate{}
+	err = cursor.All(ctx, &result)
+	return result, err
+}
+
+func (this *Mongo) GetTemplate(id string) (result model.DeploymentTemplate, exists bool, err error) {
+	ctx, _ := getTimeoutContext()
+	err = this.templatesCollection().FindOne(ctx, bson.M{templateIdKey: id}).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return result, false, nil
+	}
+	if err != nil {
+		return result, false, err
+	}
+	return result, true, nil
+}
+
+func (this *Mongo) SetTemplate(template model.DeploymentTemplate) error {
+	ctx, _ := getTimeoutContext()
+	_, err := this.templatesCollection().ReplaceOne(ctx, bson.M{templateIdKey: template.Id}, template, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (this *Mongo) RemoveTemplate(id string) error {
+	ctx, _ := getTimeoutContext()
+	_, err := this.templatesCollection().DeleteOne(ctx, bson.M{templateIdKey: id})
+	return err
+}
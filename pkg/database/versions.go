@@ -0,0 +1,114 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database
+
+import (
+	"errors"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"runtime/debug"
+)
+
+var versionHubIdKey string
+var versionDeploymentIdKey string
+var versionVersionKey string
+
+func init() {
+	CreateCollections = append(CreateCollections, func(db *Mongo, config configuration.Config) error {
+		var err error
+		for fieldName, key := range map[string]*string{
+			"HubId":        &versionHubIdKey,
+			"DeploymentId": &versionDeploymentIdKey,
+			"Version":      &versionVersionKey,
+		} {
+			*key, err = getBsonFieldName(model.DeploymentVersion{}, fieldName)
+			if err != nil {
+				debug.PrintStack()
+				return err
+			}
+		}
+		err = db.ensureCompoundIndex(db.versionsCollection(), "deploymentversionindex", true, true, versionHubIdKey, versionDeploymentIdKey, versionVersionKey)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) versionsCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoVersionCollection)
+}
+
+// AddDeploymentVersion stores the version with the next free version number
+func (this *Mongo) AddDeploymentVersion(version model.DeploymentVersion) (result model.DeploymentVersion, err error) {
+	//the unique index rejects concurrently assigned version numbers; retry with the next one
+	for i := 0; i < 3; i++ {
+		ctx, _ := getTimeoutContext()
+		latest := model.DeploymentVersion{}
+		err = this.versionsCollection().FindOne(ctx,
+			bson.M{versionHubIdKey: version.HubId, versionDeploymentIdKey: version.DeploymentId},
+			options.FindOne().SetSort(bson.D{{Key: versionVersionKey, Value: -1}})).Decode(&latest)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return result, err
+		}
+		version.Version = latest.Version + 1
+		_, err = this.versionsCollection().InsertOne(ctx, version)
+		if mongo.IsDuplicateKeyError(err) {
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		return version, nil
+	}
+	return result, err
+}
+
+func (this *Mongo) ListDeploymentVersions(hubId string, deploymentId string) (result []model.DeploymentVersion, err error) {
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.versionsCollection().Find(ctx,
+		bson.M{versionHubIdKey: hubId, versionDeploymentIdKey: deploymentId},
+		options.Find().SetSort(bson.D{{Key: versionVersionKey, Value: 1}}))
+	if err != nil {
+		return result, err
+	}
+	result = []model.DeploymentVersion{}
+	err = cursor.All(ctx, &result)
+	return result, err
+}
+
+func (this *Mongo) GetDeploymentVersion(hubId string, deploymentId string, version int) (result model.DeploymentVersion, exists bool, err error) {
+	ctx, _ := getTimeoutContext()
+	err = this.versionsCollection().FindOne(ctx, bson.M{versionHubIdKey: hubId, versionDeploymentIdKey: deploymentId, versionVersionKey: version}).Decode(&result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return result, false, nil
+	}
+	if err != nil {
+		return result, false, err
+	}
+	return result, true, nil
+}
+
+func (this *Mongo) RemoveDeploymentVersions(hubId string, deploymentId string) error {
+	ctx, _ := getTimeoutContext()
+	_, err := this.versionsCollection().DeleteMany(ctx, bson.M{versionHubIdKey: hubId, versionDeploymentIdKey: deploymentId})
+	return err
+}
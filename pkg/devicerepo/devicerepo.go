@@ -18,52 +18,89 @@ package devicerepo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/SENERGY-Platform/process-deployment/lib/auth"
 	"github.com/SENERGY-Platform/process-deployment/lib/interfaces"
 	"github.com/SENERGY-Platform/process-deployment/lib/model/devicemodel"
 	"github.com/SENERGY-Platform/process-deployment/lib/model/deviceselectionmodel"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/metrics"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"runtime/debug"
 	"time"
 )
 
-var Factory controller.DeviceRepoFactory = func(config configuration.Config, reuse interfaces.Devices, hubId string) interfaces.Devices {
+var Factory controller.DeviceRepoFactory = func(ctx context.Context, config configuration.Config, reuse interfaces.Devices, hubId string) interfaces.Devices {
 	return &DeviceRepo{
+		ctx:    ctx,
 		config: config,
 		hubId:  hubId,
 		reuse:  reuse,
 	}
 }
 
+// upstream labels of the metrics and prefixes of the span names
+const (
+	upstreamDeviceRepo      = "device-repository"
+	upstreamDeviceSelection = "device-selection"
+	upstreamPermissions     = "permissions-v2"
+)
+
 type DeviceRepo struct {
+	ctx    context.Context //parent of the spans and requests; the interfaces.Devices methods don't receive a context
 	config configuration.Config
 	hubId  string
 	reuse  interfaces.Devices
 }
 
 func (this *DeviceRepo) GetDeviceGroup(token auth.Token, id string) (result devicemodel.DeviceGroup, err error, code int) {
-	return this.reuse.GetDeviceGroup(token, id)
+	start := time.Now()
+	result, err, code = this.reuse.GetDeviceGroup(token, id)
+	metrics.ObserveUpstreamCall(upstreamDeviceRepo, "get-device-group", start, code, err)
+	err = upstreamError(upstreamDeviceRepo, code, err)
+	return result, err, code
 }
 
 func (this *DeviceRepo) GetAspectNode(token auth.Token, id string) (aspectNode devicemodel.AspectNode, err error) {
-	return this.reuse.GetAspectNode(token, id)
+	start := time.Now()
+	aspectNode, err = this.reuse.GetAspectNode(token, id)
+	metrics.ObserveUpstreamCall(upstreamDeviceRepo, "get-aspect-node", start, 0, err)
+	err = upstreamError(upstreamDeviceRepo, 0, err)
+	return aspectNode, err
 }
 
-func (this *DeviceRepo) GetDevice(token auth.Token, id string) (devicemodel.Device, error, int) {
-	return this.reuse.GetDevice(token, id)
+func (this *DeviceRepo) GetDevice(token auth.Token, id string) (result devicemodel.Device, err error, code int) {
+	start := time.Now()
+	result, err, code = this.reuse.GetDevice(token, id)
+	metrics.ObserveUpstreamCall(upstreamDeviceRepo, "get-device", start, code, err)
+	err = upstreamError(upstreamDeviceRepo, code, err)
+	return result, err, code
 }
 
-func (this *DeviceRepo) GetService(token auth.Token, id string) (devicemodel.Service, error, int) {
-	return this.reuse.GetService(token, id)
+func (this *DeviceRepo) GetService(token auth.Token, id string) (result devicemodel.Service, err error, code int) {
+	start := time.Now()
+	result, err, code = this.reuse.GetService(token, id)
+	metrics.ObserveUpstreamCall(upstreamDeviceRepo, "get-service", start, code, err)
+	err = upstreamError(upstreamDeviceRepo, code, err)
+	return result, err, code
 }
 
-func (this *DeviceRepo) CheckAccess(token auth.Token, kind string, ids []string) (map[string]bool, error) {
-	return this.reuse.CheckAccess(token, kind, ids)
+func (this *DeviceRepo) CheckAccess(token auth.Token, kind string, ids []string) (result map[string]bool, err error) {
+	start := time.Now()
+	result, err = this.reuse.CheckAccess(token, kind, ids)
+	metrics.ObserveUpstreamCall(upstreamPermissions, "check-access", start, 0, err)
+	err = upstreamError(upstreamPermissions, 0, err)
+	return result, err
 }
 
 // deprecated
@@ -76,8 +113,12 @@ type BulkRequestElementWithLocalDeviceFilter struct {
 	LocalDevices []string `json:"local_devices"`
 }
 
+// the bulk selections are requested directly instead of using this.reuse,
+// because the requests of the reused repository don't carry ctx and with it the trace of the api request
 func (this *DeviceRepo) GetBulkDeviceSelectionV2(token auth.Token, bulk deviceselectionmodel.BulkRequestV2) (result deviceselectionmodel.BulkResult, err error, code int) {
-	hub, err, code := this.GetHub(token.Jwt(), this.hubId)
+	ctx, span := tracing.StartSpan(this.ctx, upstreamDeviceSelection+" bulk-selectables-v2", attribute.String("hub.id", this.hubId), attribute.Int("bulk.size", len(bulk)))
+	defer func() { tracing.EndSpan(span, err) }()
+	hub, err, code := this.getHub(ctx, token.Jwt(), this.hubId)
 	if err != nil {
 		return result, err, code
 	}
@@ -85,11 +126,13 @@ func (this *DeviceRepo) GetBulkDeviceSelectionV2(token auth.Token, bulk devicese
 		element.LocalDevices = hub.DeviceLocalIds
 		bulk[i] = element
 	}
-	return this.reuse.GetBulkDeviceSelectionV2(token, bulk)
+	return this.postBulkSelection(ctx, token, "/v2/bulk/selectables?complete_services=true", "bulk-selectables-v2", bulk)
 }
 
 func (this *DeviceRepo) GetBulkDeviceSelection(token auth.Token, bulk deviceselectionmodel.BulkRequest) (result deviceselectionmodel.BulkResult, err error, code int) {
-	hub, err, code := this.GetHub(token.Jwt(), this.hubId)
+	ctx, span := tracing.StartSpan(this.ctx, upstreamDeviceSelection+" bulk-selectables", attribute.String("hub.id", this.hubId), attribute.Int("bulk.size", len(bulk)))
+	defer func() { tracing.EndSpan(span, err) }()
+	hub, err, code := this.getHub(ctx, token.Jwt(), this.hubId)
 	if err != nil {
 		return result, err, code
 	}
@@ -100,20 +143,35 @@ func (this *DeviceRepo) GetBulkDeviceSelection(token auth.Token, bulk devicesele
 			LocalDevices:       hub.DeviceLocalIds,
 		})
 	}
+	return this.postBulkSelection(ctx, token, "/bulk/selectables", "bulk-selectables", bulkWithLocalDevices)
+}
 
+// posts a bulk request to the device-selection; endpoint is the metrics label of the request
+func (this *DeviceRepo) postBulkSelection(ctx context.Context, token auth.Token, path string, endpoint string, bulk interface{}) (result deviceselectionmodel.BulkResult, err error, code int) {
+	if this.config.Debug {
+		temp, _ := json.Marshal(bulk)
+		log.Println("DEBUG: send", endpoint, "with:\n", string(temp))
+	}
+	var timeout time.Duration
+	if this.config.DeviceSelectionTimeout != "" {
+		timeout, err = time.ParseDuration(this.config.DeviceSelectionTimeout)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+	}
 	client := http.Client{
-		Timeout: 5 * time.Second,
+		Timeout:   timeout,
+		Transport: tracing.NewTransport(metrics.NewTransport(upstreamDeviceSelection, endpoint)),
 	}
 
 	buff := new(bytes.Buffer)
-	err = json.NewEncoder(buff).Encode(bulkWithLocalDevices)
+	err = json.NewEncoder(buff).Encode(bulk)
 	if err != nil {
 		debug.PrintStack()
 		return result, err, http.StatusInternalServerError
 	}
-
-	path := "/bulk/selectables"
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"POST",
 		this.config.DeviceSelectionUrl+path,
 		buff,
@@ -127,22 +185,34 @@ func (this *DeviceRepo) GetBulkDeviceSelection(token auth.Token, bulk devicesele
 	resp, err := client.Do(req)
 	if err != nil {
 		debug.PrintStack()
-		return result, err, http.StatusInternalServerError
+		return result, upstreamError(upstreamDeviceSelection, 0, err), http.StatusBadGateway
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode >= 300 {
+		temp, _ := io.ReadAll(resp.Body)
 		debug.PrintStack()
-		return result, errors.New("unexpected statuscode"), resp.StatusCode
+		return result, upstreamError(upstreamDeviceSelection, resp.StatusCode, errors.New("unable to load selectables: "+string(temp))), resp.StatusCode
 	}
 	err = json.NewDecoder(resp.Body).Decode(&result)
-	return result, err, resp.StatusCode
+	if err != nil {
+		return result, upstreamError(upstreamDeviceSelection, 0, fmt.Errorf("invalid selectables response: %w", err)), http.StatusBadGateway
+	}
+	return result, nil, resp.StatusCode
 }
 
 func (this *DeviceRepo) GetHub(token string, id string) (result devicemodel.Hub, err error, code int) {
+	return this.getHub(this.ctx, token, id)
+}
+
+func (this *DeviceRepo) getHub(ctx context.Context, token string, id string) (result devicemodel.Hub, err error, code int) {
+	ctx, span := tracing.StartSpan(ctx, upstreamDeviceRepo+" get-hub", attribute.String("hub.id", id))
+	defer func() { tracing.EndSpan(span, err) }()
 	client := http.Client{
-		Timeout: 5 * time.Second,
+		Timeout:   5 * time.Second,
+		Transport: tracing.NewTransport(metrics.NewTransport(upstreamDeviceRepo, "get-hub")),
 	}
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"GET",
 		this.config.DeviceRepoUrl+"/hubs/"+url.PathEscape(id),
 		nil,
@@ -156,13 +226,13 @@ func (this *DeviceRepo) GetHub(token string, id string) (result devicemodel.Hub,
 	resp, err := client.Do(req)
 	if err != nil {
 		debug.PrintStack()
-		return result, err, http.StatusInternalServerError
+		return result, upstreamError(upstreamDeviceRepo, 0, err), http.StatusBadGateway
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 300 {
 		debug.PrintStack()
-		return result, errors.New("unexpected statuscode"), resp.StatusCode
+		return result, upstreamError(upstreamDeviceRepo, resp.StatusCode, errors.New("unexpected statuscode")), resp.StatusCode
 	}
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	if err != nil {
@@ -170,3 +240,15 @@ func (this *DeviceRepo) GetHub(token string, id string) (result devicemodel.Hub,
 	}
 	return
 }
+
+// adds the upstream service to the error, so that it can be reported to clients (see model.Problem)
+func upstreamError(upstream string, code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	result := &model.Error{Upstream: upstream, Err: err}
+	if code == 0 || code >= 500 {
+		result.Code = model.ErrorCodeUpstream
+	}
+	return result
+}
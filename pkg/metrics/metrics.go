@@ -0,0 +1,114 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const namespace = "process_fog_deployment"
+
+var (
+	ApiRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_requests_total",
+		Help:      "handled api requests by route and response status",
+	}, []string{"method", "route", "status"})
+
+	ApiRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "api_request_duration_seconds",
+		Help:      "duration of handled api requests by route",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	PrepareDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "prepare_duration_seconds",
+		Help:      "duration of deployment preparations, including the device-selection requests",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	})
+
+	RateLimitedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "api requests rejected with status 429 by the exceeded limit ('user', 'hub' or 'prepare')",
+	}, []string{"limit"})
+
+	UpstreamRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_requests_total",
+		Help:      "requests to upstream services by operation and result; the status is the http status code, 'timeout' or 'error'",
+	}, []string{"upstream", "operation", "status"})
+
+	UpstreamRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "duration of requests to upstream services by operation",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"upstream", "operation"})
+)
+
+// ObserveUpstreamCall records a finished call to an upstream service.
+// code may be 0 if the call does not provide a status code.
+func ObserveUpstreamCall(upstream string, operation string, start time.Time, code int, err error) {
+	UpstreamRequestDuration.WithLabelValues(upstream, operation).Observe(time.Since(start).Seconds())
+	UpstreamRequests.WithLabelValues(upstream, operation, upstreamStatus(code, err)).Inc()
+}
+
+func upstreamStatus(code int, err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()):
+		return "timeout"
+	case code != 0:
+		return strconv.Itoa(code)
+	case err != nil:
+		return "error"
+	default:
+		return "ok"
+	}
+}
+
+// NewTransport returns a http.RoundTripper that records every request as call of the given upstream operation
+func NewTransport(upstream string, operation string) http.RoundTripper {
+	return &transport{upstream: upstream, operation: operation, next: http.DefaultTransport}
+}
+
+type transport struct {
+	upstream  string
+	operation string
+	next      http.RoundTripper
+}
+
+func (this *transport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	start := time.Now()
+	resp, err = this.next.RoundTrip(req)
+	code := 0
+	if resp != nil {
+		code = resp.StatusCode
+	}
+	ObserveUpstreamCall(this.upstream, this.operation, start, code, err)
+	return resp, err
+}
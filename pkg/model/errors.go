@@ -0,0 +1,66 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// ErrorCode is a stable identifier of an error, meant to be evaluated by clients instead of the error message
+type ErrorCode string
+
+const (
+	ErrorCodeBadRequest                 ErrorCode = "bad_request"
+	ErrorCodeUnauthorized               ErrorCode = "unauthorized"
+	ErrorCodeForbidden                  ErrorCode = "forbidden"
+	ErrorCodeNotFound                   ErrorCode = "not_found"
+	ErrorCodeMethodNotAllowed           ErrorCode = "method_not_allowed"
+	ErrorCodeConflict                   ErrorCode = "conflict"
+	ErrorCodeTooManyRequests            ErrorCode = "too_many_requests"
+	ErrorCodeInternal                   ErrorCode = "internal_error"
+	ErrorCodeUpstream                   ErrorCode = "upstream_error"       //an upstream service responded with an error
+	ErrorCodeUpstreamUnavailable        ErrorCode = "upstream_unavailable" //an upstream service could not be reached
+	ErrorCodeInvalidStartParameters     ErrorCode = "invalid_start_parameters"
+	ErrorCodeMessageEventsUnsupported   ErrorCode = "message_events_unsupported"
+	ErrorCodeImportSelectionUnsupported ErrorCode = "import_selection_unsupported"
+	ErrorCodeRollbackFailed             ErrorCode = "rollback_failed" //a failed update could not be rolled back; the hub may have both or none of the versions deployed
+)
+
+// Problem is the body of error responses (application/problem+json, see RFC 9457)
+type Problem struct {
+	Title            string            `json:"title"`
+	Status           int               `json:"status"`
+	Detail           string            `json:"detail"`
+	Code             ErrorCode         `json:"code"`
+	Upstream         string            `json:"upstream,omitempty"` //name of the upstream service that caused the error
+	BpmnId           string            `json:"bpmn_id,omitempty"`  //bpmn id of the element that caused the error
+	RequestId        string            `json:"request_id,omitempty"`
+	StartedInstances []StartedInstance `json:"started_instances,omitempty"` //instances started before a start of multiple camunda deployments failed
+}
+
+// Error adds the details of a Problem to an error
+type Error struct {
+	Code             ErrorCode
+	Upstream         string
+	BpmnId           string
+	StartedInstances []StartedInstance
+	Err              error
+}
+
+func (this *Error) Error() string {
+	return this.Err.Error()
+}
+
+func (this *Error) Unwrap() error {
+	return this.Err
+}
@@ -44,3 +44,246 @@ type Variable struct {
 	Type      string      `json:"type"`
 	ValueInfo interface{} `json:"valueInfo"`
 }
+
+type FogDeployment struct {
+	Id                   string          `json:"id"`
+	Name                 string          `json:"name"`
+	CamundaDeploymentIds []string        `json:"camunda_deployment_ids"`
+	IsPlaceholder        bool            `json:"is_placeholder"`
+	MarkedForDelete      bool            `json:"marked_for_delete"`
+	SyncDate             time.Time       `json:"sync_date"`
+	State                DeploymentState `json:"state"`
+}
+
+type FogDeploymentInfo struct {
+	CamundaDeploymentId string                     `json:"camunda_deployment_id"`
+	Deployment          deploymentmodel.Deployment `json:"deployment"`
+	State               DeploymentState            `json:"state"`
+	SyncInfo
+}
+
+// HubDeployments lists the fog deployments of a hub for admins
+type HubDeployments struct {
+	HubId       string          `json:"hub_id"`
+	OwnerId     string          `json:"owner_id"` //empty if the hub could not be read from the device-repository
+	Deployments []FogDeployment `json:"deployments"`
+	Error       string          `json:"error,omitempty"` //set if the deployments of the hub could not be listed
+}
+
+// AdminRemoveResult reports a forced removal; camunda deployments that could not be removed are listed in Errors
+type AdminRemoveResult struct {
+	DeploymentStateInfo
+	Removed []string `json:"removed"` //ids of the removed camunda deployments
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// DeploymentState is the lifecycle state of a fog deployment, derived from SyncInfo
+type DeploymentState string
+
+const (
+	DeploymentStatePendingSync   DeploymentState = "pending-sync"   //deployed to process-sync but not yet confirmed by the hub
+	DeploymentStateActive        DeploymentState = "active"         //synced with the hub
+	DeploymentStatePendingDelete DeploymentState = "pending-delete" //removed in process-sync but not yet confirmed by the hub
+	DeploymentStateStale         DeploymentState = "stale"          //pending change that has not been confirmed by the hub in time
+)
+
+type DeploymentWithState struct {
+	deploymentmodel.Deployment
+	State DeploymentState `json:"state"`
+}
+
+type DeploymentStateInfo struct {
+	Id    string          `json:"id"`
+	State DeploymentState `json:"state"`
+}
+
+type DeploymentTransferResult struct {
+	Deployed   bool                       `json:"deployed"`
+	Deployment deploymentmodel.Deployment `json:"deployment"`
+	State      DeploymentState            `json:"state,omitempty"`
+	Unmatched  []UnmatchedSelection       `json:"unmatched"`
+}
+
+// UnmatchedSelection describes a task or event whose selection could not be resolved on the target hub
+type UnmatchedSelection struct {
+	BpmnId string `json:"bpmn_id"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+type ValidationResult struct {
+	Valid      bool                        `json:"valid"`
+	Problems   []ValidationProblem         `json:"problems"`
+	Deployment *deploymentmodel.Deployment `json:"deployment,omitempty"`
+}
+
+type ValidationProblem struct {
+	BpmnId  string    `json:"bpmn_id,omitempty"`
+	Code    ErrorCode `json:"code,omitempty"`
+	Message string    `json:"message"`
+}
+
+type ProcessDefinition struct {
+	Id           string `json:"id"`
+	Key          string `json:"key"`
+	Name         string `json:"name"`
+	Version      int    `json:"version"`
+	DeploymentId string `json:"deploymentId"`
+	Suspended    bool   `json:"suspended"`
+	TenantId     string `json:"tenantId"`
+	SyncInfo
+}
+
+type ProcessInstance struct {
+	Id             string `json:"id"`
+	DefinitionId   string `json:"definitionId"`
+	BusinessKey    string `json:"businessKey"`
+	CaseInstanceId string `json:"caseInstanceId"`
+	Ended          bool   `json:"ended"`
+	Suspended      bool   `json:"suspended"`
+	TenantId       string `json:"tenantId"`
+	SyncInfo
+}
+
+type HistoricProcessInstance struct {
+	Id                       string  `json:"id"`
+	SuperProcessInstanceId   string  `json:"superProcessInstanceId"`
+	SuperCaseInstanceId      string  `json:"superCaseInstanceId"`
+	CaseInstanceId           string  `json:"caseInstanceId"`
+	ProcessDefinitionName    string  `json:"processDefinitionName"`
+	ProcessDefinitionKey     string  `json:"processDefinitionKey"`
+	ProcessDefinitionVersion float64 `json:"processDefinitionVersion"`
+	ProcessDefinitionId      string  `json:"processDefinitionId"`
+	BusinessKey              string  `json:"businessKey"`
+	StartTime                string  `json:"startTime"`
+	EndTime                  string  `json:"endTime"`
+	DurationInMillis         float64 `json:"durationInMillis"`
+	StartUserId              string  `json:"startUserId"`
+	StartActivityId          string  `json:"startActivityId"`
+	DeleteReason             string  `json:"deleteReason"`
+	TenantId                 string  `json:"tenantId"`
+	State                    string  `json:"state"`
+	SyncInfo
+}
+
+type Incident struct {
+	Id                  string    `json:"id"`
+	ExternalTaskId      string    `json:"external_task_id"`
+	ProcessInstanceId   string    `json:"process_instance_id"`
+	ProcessDefinitionId string    `json:"process_definition_id"`
+	ActivityId          string    `json:"activity_id"`
+	WorkerId            string    `json:"worker_id"`
+	ErrorMessage        string    `json:"error_message"`
+	Time                time.Time `json:"time"`
+	TenantId            string    `json:"tenant_id"`
+	DeploymentName      string    `json:"deployment_name"`
+	SyncInfo
+}
+
+// FogIncident is an incident of one of the camunda deployments behind a fog deployment
+type FogIncident struct {
+	Id                  string    `json:"id"`
+	CamundaDeploymentId string    `json:"camunda_deployment_id"`
+	ProcessInstanceId   string    `json:"process_instance_id"`
+	ProcessDefinitionId string    `json:"process_definition_id"`
+	BpmnId              string    `json:"bpmn_id"` //camunda activity id of the failed element
+	ErrorMessage        string    `json:"error_message"`
+	Time                time.Time `json:"time"`
+	SyncInfo
+}
+
+type StartResult struct {
+	BusinessKey string            `json:"business_key"`
+	Instances   []StartedInstance `json:"instances"`
+}
+
+type StartedInstance struct {
+	CamundaDeploymentId string `json:"camunda_deployment_id"`
+	ProcessInstanceId   string `json:"process_instance_id,omitempty"` //empty if process-sync does not report the created instance (older process-sync versions)
+}
+
+// JsonSchema is the subset of JSON Schema used to describe start parameters
+type JsonSchema struct {
+	Schema               string                `json:"$schema,omitempty"`
+	Title                string                `json:"title,omitempty"`
+	Type                 string                `json:"type,omitempty"`
+	Format               string                `json:"format,omitempty"`
+	Properties           map[string]JsonSchema `json:"properties,omitempty"`
+	Required             []string              `json:"required,omitempty"`
+	AdditionalProperties *bool                 `json:"additionalProperties,omitempty"`
+	Default              interface{}           `json:"default,omitempty"`
+}
+
+// Schedule starts a fog deployment repeatedly, either by cron expression or by interval
+type Schedule struct {
+	Id           string                 `json:"id"`
+	UserId       string                 `json:"user_id"`
+	HubId        string                 `json:"hub_id"`
+	DeploymentId string                 `json:"deployment_id"`
+	Cron         string                 `json:"cron,omitempty"`     //standard cron expression, e.g. "0 6 * * 1-5"
+	Interval     string                 `json:"interval,omitempty"` //go duration, e.g. "90m"
+	Inputs       map[string]interface{} `json:"inputs,omitempty"`
+	Disabled     bool                   `json:"disabled"`
+	LastRun      *time.Time             `json:"last_run,omitempty"`
+	LastError    string                 `json:"last_error,omitempty"`
+	NextRun      time.Time              `json:"next_run"`
+}
+
+// DeploymentVersion is a deployment model as it has been deployed to the hub
+type DeploymentVersion struct {
+	HubId        string                     `json:"hub_id"`
+	DeploymentId string                     `json:"deployment_id"`
+	Version      int                        `json:"version"`
+	UserId       string                     `json:"user_id"`
+	Date         time.Time                  `json:"date"`
+	Deployment   deploymentmodel.Deployment `json:"deployment"`
+}
+
+// DeploymentTemplate is a prepared deployment that can be applied to any hub.
+// task and event selections are stored as filter criteria only; concrete devices are selected when the template is applied.
+// the placeholders {{hub_id}} and {{hub_name}} in name and description are replaced with the values of the target hub.
+type DeploymentTemplate struct {
+	Id         string                     `json:"id"`
+	UserId     string                     `json:"user_id"`
+	Name       string                     `json:"name"`
+	Date       time.Time                  `json:"date"`
+	Deployment deploymentmodel.Deployment `json:"deployment"`
+}
+
+const DeploymentBundleFormatVersion = 1
+
+// DeploymentBundleManifest is stored as manifest.json in exported deployment bundles
+type DeploymentBundleManifest struct {
+	FormatVersion     int       `json:"format_version"`
+	SourceHubId       string    `json:"source_hub_id"`
+	DeploymentId      string    `json:"deployment_id"`
+	DeploymentName    string    `json:"deployment_name"`
+	DeploymentVersion int       `json:"deployment_version,omitempty"` //latest recorded version, see DeploymentVersion
+	ExportDate        time.Time `json:"export_date"`
+}
+
+// DeploymentDiff lists the differences between two deployments; elements are matched by their bpmn id
+type DeploymentDiff struct {
+	Changed bool               `json:"changed"`
+	Changes []DeploymentChange `json:"changes"`
+}
+
+type DeploymentChange struct {
+	BpmnId string      `json:"bpmn_id,omitempty"` //empty for changes of the deployment itself
+	Field  string      `json:"field"`             //json path of the field, e.g. "task.selection.selected_device_id" or "task.parameter.inputs"
+	Old    interface{} `json:"old"`               //nil if the field or element is added
+	New    interface{} `json:"new"`               //nil if the field or element is removed
+}
+
+type ReadinessReport struct {
+	Ready        bool               `json:"ready"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+type DependencyStatus struct {
+	Name      string `json:"name"`
+	Ready     bool   `json:"ready"`
+	LatencyMs int64  `json:"latency_ms"`
+	Status    int    `json:"status,omitempty"` //http status code of the response
+	Error     string `json:"error,omitempty"`
+}
@@ -17,12 +17,23 @@
 package pkg
 
 import (
+	"context"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/database"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/devicerepo"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/processsync"
 )
 
-func NewController(config configuration.Config) (*controller.Controller, error) {
-	return controller.New(config, processsync.New(config), devicerepo.Factory)
+func NewController(ctx context.Context, config configuration.Config) (*controller.Controller, error) {
+	db, err := database.New(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	ctrl, err := controller.New(config, processsync.New(config), devicerepo.Factory, db)
+	if err != nil {
+		return nil, err
+	}
+	ctrl.StartScheduler(ctx)
+	return ctrl, nil
 }
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package processsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/metrics"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+func (this *ProcessSync) ProcessDefinitions(ctx context.Context, token string, hubId string) (result []model.ProcessDefinition, err error, code int) {
+	err, code = this.get(ctx, token, hubId, "process-definitions", "/process-definitions?network_id="+url.QueryEscape(hubId), &result)
+	return result, err, code
+}
+
+func (this *ProcessSync) ProcessInstances(ctx context.Context, token string, hubId string) (result []model.ProcessInstance, err error, code int) {
+	err, code = this.get(ctx, token, hubId, "process-instances", "/process-instances?network_id="+url.QueryEscape(hubId), &result)
+	return result, err, code
+}
+
+func (this *ProcessSync) HistoricProcessInstances(ctx context.Context, token string, hubId string) (result []model.HistoricProcessInstance, err error, code int) {
+	err, code = this.get(ctx, token, hubId, "historic-process-instances", "/history/process-instances?network_id="+url.QueryEscape(hubId), &result)
+	return result, err, code
+}
+
+func (this *ProcessSync) StopProcessInstance(ctx context.Context, token string, hubId string, instanceId string) (err error, code int) {
+	return this.delete(ctx, token, hubId, "stop-process-instance", "/process-instances/"+url.PathEscape(hubId)+"/"+url.PathEscape(instanceId))
+}
+
+func (this *ProcessSync) DeleteHistoricProcessInstance(ctx context.Context, token string, hubId string, instanceId string) (err error, code int) {
+	return this.delete(ctx, token, hubId, "delete-historic-process-instance", "/history/process-instances/"+url.PathEscape(hubId)+"/"+url.PathEscape(instanceId))
+}
+
+func (this *ProcessSync) get(ctx context.Context, token string, hubId string, operation string, path string, result interface{}) (err error, code int) {
+	ctx, span := tracing.StartSpan(ctx, upstream+" "+operation, attribute.String("hub.id", hubId))
+	defer func() { tracing.EndSpan(span, err) }()
+	req, err := http.NewRequestWithContext(ctx, "GET", this.config.ProcessSyncUrl+path, nil)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+
+	req.Header.Set("Authorization", token)
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: tracing.NewTransport(metrics.NewTransport(upstream, operation)),
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return unavailableError(err), http.StatusBadGateway
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(resp.Body)
+		return upstreamError(resp.StatusCode, buf.String()), resp.StatusCode
+	}
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if err != nil {
+		_, _ = io.ReadAll(resp.Body) //ensure empty body to enable connection reuse and prevent memory leaks
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+func (this *ProcessSync) delete(ctx context.Context, token string, hubId string, operation string, path string) (err error, code int) {
+	ctx, span := tracing.StartSpan(ctx, upstream+" "+operation, attribute.String("hub.id", hubId))
+	defer func() { tracing.EndSpan(span, err) }()
+	req, err := http.NewRequestWithContext(ctx, "DELETE", this.config.ProcessSyncUrl+path, nil)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+
+	req.Header.Set("Authorization", token)
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: tracing.NewTransport(metrics.NewTransport(upstream, operation)),
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return unavailableError(err), http.StatusBadGateway
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(resp.Body)
+		err = upstreamError(resp.StatusCode, buf.String())
+	}
+	_, _ = io.ReadAll(resp.Body) //ensure empty body to enable connection reuse and prevent memory leaks
+	return err, resp.StatusCode
+}
+
+func (this *ProcessSync) Incidents(ctx context.Context, token string, hubId string) (result []model.Incident, err error, code int) {
+	err, code = this.get(ctx, token, hubId, "incidents", "/incidents?network_id="+url.QueryEscape(hubId), &result)
+	return result, err, code
+}
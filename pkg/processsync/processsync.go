@@ -18,14 +18,19 @@ package processsync
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/metrics"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -35,106 +40,169 @@ func New(config configuration.Config) *ProcessSync {
 	}
 }
 
+// upstream label of the metrics and prefix of the span names
+const upstream = "process-sync"
+
 type ProcessSync struct {
 	config configuration.Config
 }
 
-func (this *ProcessSync) Deploy(token string, hubId string, deployment deploymentmodel.Deployment) error {
+// longer error responses of process-sync are truncated
+const maxErrorBodyLength = 500
+
+// wraps an error response of process-sync; responses with status < 500 keep the error code derived from the status
+func upstreamError(code int, body string) error {
+	body = strings.TrimSpace(body)
+	if len(body) > maxErrorBodyLength {
+		body = body[:maxErrorBodyLength] + "..."
+	}
+	result := &model.Error{Upstream: upstream, Err: fmt.Errorf("%v responded with %v: %v", upstream, code, body)}
+	if code >= 500 {
+		result.Code = model.ErrorCodeUpstream
+	}
+	return result
+}
+
+// wraps errors of requests that did not receive a response
+func unavailableError(err error) error {
+	return &model.Error{Code: model.ErrorCodeUpstreamUnavailable, Upstream: upstream, Err: err}
+}
+
+func (this *ProcessSync) Deploy(ctx context.Context, token string, hubId string, deployment deploymentmodel.Deployment) (err error) {
+	ctx, span := tracing.StartSpan(ctx, upstream+" deploy", attribute.String("hub.id", hubId), attribute.String("deployment.id", deployment.Id))
+	defer func() { tracing.EndSpan(span, err) }()
 	requestBody := new(bytes.Buffer)
-	err := json.NewEncoder(requestBody).Encode(deployment)
+	err = json.NewEncoder(requestBody).Encode(deployment)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("POST", this.config.ProcessSyncUrl+"/deployments/"+url.PathEscape(hubId), requestBody)
+	req, err := http.NewRequestWithContext(ctx, "POST", this.config.ProcessSyncUrl+"/deployments/"+url.PathEscape(hubId), requestBody)
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set("Authorization", token)
 	client := &http.Client{
-		Timeout: 10 * time.Second,
+		Timeout:   10 * time.Second,
+		Transport: tracing.NewTransport(metrics.NewTransport(upstream, "deploy")),
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return unavailableError(err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode >= 300 {
 		buf := new(bytes.Buffer)
 		buf.ReadFrom(resp.Body)
-		err = errors.New(buf.String())
+		err = upstreamError(resp.StatusCode, buf.String())
 	}
 	_, _ = io.ReadAll(resp.Body) //ensure empty body to enable connection reuse and prevent memory leaks
 	return err
 }
 
-func (this *ProcessSync) Start(token string, hubId string, deploymentId string, inputs url.Values) (error, int) {
-	req, err := http.NewRequest("GET", this.config.ProcessSyncUrl+"/deployments/"+url.PathEscape(hubId)+"/"+url.PathEscape(deploymentId)+"/start?"+inputs.Encode(), nil)
+// Start starts the camunda deployment with the given start variables.
+// process-sync expects the variables as query parameters and parses each value as json (falling back to a plain string).
+// the business key is passed as the reserved query parameter 'business_key'.
+// older process-sync versions respond with 'true' instead of the created instance; the returned instance is empty in this case.
+func (this *ProcessSync) Start(ctx context.Context, token string, hubId string, deploymentId string, businessKey string, inputs map[string]interface{}) (result model.ProcessInstance, err error, code int) {
+	ctx, span := tracing.StartSpan(ctx, upstream+" start", attribute.String("hub.id", hubId), attribute.String("deployment.id", deploymentId))
+	defer func() { tracing.EndSpan(span, err) }()
+	query := url.Values{}
+	for key, value := range inputs {
+		temp, err := json.Marshal(value)
+		if err != nil {
+			return result, err, http.StatusBadRequest
+		}
+		query.Set(key, string(temp))
+	}
+	if businessKey != "" {
+		query.Set("business_key", businessKey)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", this.config.ProcessSyncUrl+"/deployments/"+url.PathEscape(hubId)+"/"+url.PathEscape(deploymentId)+"/start?"+query.Encode(), nil)
 	if err != nil {
-		return err, http.StatusInternalServerError
+		return result, err, http.StatusInternalServerError
 	}
 
 	req.Header.Set("Authorization", token)
 	client := &http.Client{
-		Timeout: 10 * time.Second,
+		Timeout:   10 * time.Second,
+		Transport: tracing.NewTransport(metrics.NewTransport(upstream, "start")),
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return err, http.StatusInternalServerError
+		return result, unavailableError(err), http.StatusBadGateway
 	}
 	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
 	if resp.StatusCode >= 300 {
-		buf := new(bytes.Buffer)
-		buf.ReadFrom(resp.Body)
-		err = errors.New(buf.String())
+		return result, upstreamError(resp.StatusCode, string(body)), resp.StatusCode
 	}
-	_, _ = io.ReadAll(resp.Body) //ensure empty body to enable connection reuse and prevent memory leaks
-	return err, resp.StatusCode
+	if strings.TrimSpace(string(body)) == "true" {
+		return result, nil, resp.StatusCode
+	}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return result, &model.Error{Code: model.ErrorCodeUpstream, Upstream: upstream, Err: fmt.Errorf("unexpected %v start response: %w", upstream, err)}, http.StatusBadGateway
+	}
+	return result, nil, resp.StatusCode
 }
 
-func (this *ProcessSync) Remove(token string, hubId string, id string) (err error, code int) {
-	req, err := http.NewRequest("DELETE", this.config.ProcessSyncUrl+"/deployments/"+url.PathEscape(hubId)+"/"+url.PathEscape(id), nil)
+func (this *ProcessSync) Remove(ctx context.Context, token string, hubId string, id string) (err error, code int) {
+	ctx, span := tracing.StartSpan(ctx, upstream+" remove", attribute.String("hub.id", hubId), attribute.String("deployment.id", id))
+	defer func() { tracing.EndSpan(span, err) }()
+	req, err := http.NewRequestWithContext(ctx, "DELETE", this.config.ProcessSyncUrl+"/deployments/"+url.PathEscape(hubId)+"/"+url.PathEscape(id), nil)
 	if err != nil {
 		return err, http.StatusInternalServerError
 	}
 
 	req.Header.Set("Authorization", token)
 	client := &http.Client{
-		Timeout: 10 * time.Second,
+		Timeout:   10 * time.Second,
+		Transport: tracing.NewTransport(metrics.NewTransport(upstream, "remove")),
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return err, http.StatusInternalServerError
+		return unavailableError(err), http.StatusBadGateway
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode >= 300 {
 		buf := new(bytes.Buffer)
 		buf.ReadFrom(resp.Body)
-		err = errors.New(buf.String())
+		err = upstreamError(resp.StatusCode, buf.String())
 	}
 	_, _ = io.ReadAll(resp.Body) //ensure empty body to enable connection reuse and prevent memory leaks
 	return err, resp.StatusCode
 }
 
-func (this *ProcessSync) Metadata(token string, hubId string, deploymentId string) (result []model.DeploymentMetadata, err error, code int) {
-	req, err := http.NewRequest("GET", this.config.ProcessSyncUrl+"/metadata/"+url.PathEscape(hubId)+"?deployment_id="+url.QueryEscape(deploymentId), nil)
+func (this *ProcessSync) Metadata(ctx context.Context, token string, hubId string, deploymentId string) (result []model.DeploymentMetadata, err error, code int) {
+	ctx, span := tracing.StartSpan(ctx, upstream+" metadata", attribute.String("hub.id", hubId), attribute.String("deployment.id", deploymentId))
+	defer func() { tracing.EndSpan(span, err) }()
+	endpoint := this.config.ProcessSyncUrl + "/metadata/" + url.PathEscape(hubId)
+	if deploymentId != "" {
+		endpoint = endpoint + "?deployment_id=" + url.QueryEscape(deploymentId)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return result, err, http.StatusInternalServerError
 	}
 
 	req.Header.Set("Authorization", token)
 	client := &http.Client{
-		Timeout: 10 * time.Second,
+		Timeout:   10 * time.Second,
+		Transport: tracing.NewTransport(metrics.NewTransport(upstream, "metadata")),
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return result, err, http.StatusInternalServerError
+		return result, unavailableError(err), http.StatusBadGateway
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode >= 300 {
 		buf := new(bytes.Buffer)
 		buf.ReadFrom(resp.Body)
-		err = errors.New(buf.String())
+		err = upstreamError(resp.StatusCode, buf.String())
 		return result, err, resp.StatusCode
 	}
 	err = json.NewDecoder(resp.Body).Decode(&result)
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tests/mocks"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAdminEndpoints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, syncCalls, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("non admin", func(t *testing.T) {
+		_, err := jsonRequest[[]model.HubDeployments](http.MethodGet, apiUrl+"/admin/deployments", nil)
+		if err == nil || !strings.HasPrefix(err.Error(), "403 ") {
+			t.Error(err)
+		}
+		_, err = jsonRequest[model.DeploymentStateInfo](http.MethodDelete, apiUrl+"/admin/deployments/"+url.PathEscape(testHubId)+"/d1", nil)
+		if err == nil || !strings.HasPrefix(err.Error(), "403 ") {
+			t.Error(err)
+		}
+		if len((*syncCalls)["/deployments/"+testHubId+"/c1"]) != 0 {
+			t.Error(*syncCalls)
+		}
+	})
+
+	t.Run("list all hubs", func(t *testing.T) {
+		result, err := Jwtget[[]model.HubDeployments](AdminJwt, apiUrl+"/admin/deployments")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result) != len(mocks.HubIds) {
+			t.Errorf("%#v", result)
+			return
+		}
+		if result[0].HubId != testHubId || result[0].OwnerId != "testowner" || len(result[0].Deployments) != 2 {
+			t.Errorf("%#v", result[0])
+		}
+		//hub is unknown to the device-repository
+		if result[1].HubId != "urn:infai:ses:hub:2" || result[1].OwnerId != "" || len(result[1].Deployments) != 1 || result[1].Deployments[0].Id != "d3" {
+			t.Errorf("%#v", result[1])
+		}
+	})
+
+	t.Run("list by owner", func(t *testing.T) {
+		result, err := Jwtget[[]model.HubDeployments](AdminJwt, apiUrl+"/admin/deployments?owner_id=testowner")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result) != 1 || result[0].HubId != testHubId {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("list by hub", func(t *testing.T) {
+		result, err := Jwtget[[]model.HubDeployments](AdminJwt, apiUrl+"/admin/deployments?hub_id="+url.QueryEscape("urn:infai:ses:hub:empty")+"&hub_id="+url.QueryEscape("urn:infai:ses:hub:2"))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		hubIds := []string{}
+		for _, hub := range result {
+			hubIds = append(hubIds, hub.HubId)
+		}
+		if !reflect.DeepEqual(hubIds, []string{"urn:infai:ses:hub:empty", "urn:infai:ses:hub:2"}) {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("list with unreadable hub", func(t *testing.T) {
+		//process-sync knows no deployments of the forbidden hub and responds with an error
+		result, err := Jwtget[[]model.HubDeployments](AdminJwt, apiUrl+"/admin/deployments?hub_id="+url.QueryEscape(mocks.ForbiddenHubId)+"&hub_id="+url.QueryEscape(testHubId))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result) != 2 {
+			t.Errorf("%#v", result)
+			return
+		}
+		if result[0].HubId != mocks.ForbiddenHubId || result[0].Error == "" || len(result[0].Deployments) != 0 {
+			t.Errorf("%#v", result[0])
+		}
+		if result[1].HubId != testHubId || result[1].Error != "" || len(result[1].Deployments) != 2 {
+			t.Errorf("%#v", result[1])
+		}
+	})
+
+	t.Run("list page", func(t *testing.T) {
+		result, err := Jwtget[[]model.HubDeployments](AdminJwt, apiUrl+"/admin/deployments?limit=1&offset=1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result) != 1 || result[0].HubId != mocks.HubIds[1] {
+			t.Errorf("%#v", result)
+		}
+		_, err = Jwtget[[]model.HubDeployments](AdminJwt, apiUrl+"/admin/deployments?limit=x")
+		if err == nil {
+			t.Error("expected error for invalid limit")
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		result, err := Jwtget[[]model.FogDeploymentInfo](AdminJwt, apiUrl+"/admin/deployments/"+url.PathEscape("urn:infai:ses:hub:2")+"/d3")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result) != 1 || result[0].CamundaDeploymentId != "c4" {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("remove without hub permission", func(t *testing.T) {
+		result, err := Jwtdelete[model.AdminRemoveResult](AdminJwt, apiUrl+"/admin/deployments/"+url.PathEscape(testHubId)+"/d1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Id != "d1" || result.State != model.DeploymentStatePendingDelete || !reflect.DeepEqual(result.Removed, []string{"c1", "c2"}) || len(result.Errors) != 0 {
+			t.Errorf("%#v", result)
+		}
+		if len((*syncCalls)["/deployments/"+testHubId+"/c1"]) != 1 {
+			t.Error(*syncCalls)
+		}
+	})
+	//process-sync fails to remove c5; c6 is removed anyway
+	t.Run("remove past errors", func(t *testing.T) {
+		result, err := Jwtdelete[model.AdminRemoveResult](AdminJwt, apiUrl+"/admin/deployments/"+url.PathEscape("urn:infai:ses:hub:2")+"/d4")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !reflect.DeepEqual(result.Removed, []string{"c6"}) || len(result.Errors) != 1 || !strings.HasPrefix(result.Errors[0], "c5: ") {
+			t.Errorf("%#v", result)
+		}
+		if len((*syncCalls)["/deployments/urn:infai:ses:hub:2/c5"]) != 1 || len((*syncCalls)["/deployments/urn:infai:ses:hub:2/c6"]) != 1 {
+			t.Error(*syncCalls)
+		}
+	})
+}
@@ -0,0 +1,332 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDeploymentBundle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, syncCalls, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	deploymentsUrl := apiUrl + "/deployments/" + url.PathEscape(testHubId)
+
+	t.Run("export", func(t *testing.T) {
+		bundle, err := bundleRequest("GET", deploymentsUrl+"/d1/export", "", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		files, err := unzip(bundle)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		manifest := model.DeploymentBundleManifest{}
+		err = json.Unmarshal(files[controller.BundleManifestFile], &manifest)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if manifest.FormatVersion != model.DeploymentBundleFormatVersion || manifest.SourceHubId != testHubId || manifest.DeploymentId != "d1" || manifest.DeploymentName != "first" {
+			t.Errorf("%#v", manifest)
+		}
+		deployment := deploymentmodel.Deployment{}
+		err = json.Unmarshal(files[controller.BundleDeploymentFile], &deployment)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if deployment.Id != "d1" || deployment.Name != "first" {
+			t.Error(deployment.Id, deployment.Name)
+		}
+		parameters := map[string]model.Variable{}
+		err = json.Unmarshal(files[controller.BundleStartParametersFile], &parameters)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(parameters) != 3 || parameters["count"].Type != "Integer" {
+			t.Errorf("%#v", parameters)
+		}
+		for _, name := range []string{controller.BundleBpmnFile, controller.BundleSvgFile} {
+			if _, ok := files[name]; !ok {
+				t.Error("missing", name)
+			}
+		}
+
+		//the test metadata contains no bpmn
+		_, err = bundleRequest("POST", apiUrl+"/deployment-imports/"+url.PathEscape(testHubId), "application/zip", bundle)
+		if err == nil || !strings.HasPrefix(err.Error(), "400") || !strings.Contains(err.Error(), "missing diagram.bpmn in bundle") {
+			t.Error(err)
+		}
+	})
+
+	processes := map[string]json.RawMessage{}
+	f, err := os.Open("resources/processes.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer f.Close()
+	err = json.NewDecoder(f).Decode(&processes)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	process := struct {
+		Bpmn string `json:"bpmn_xml"`
+		Svg  string `json:"svgXML"`
+	}{}
+	err = json.Unmarshal(processes["/processes/e32329bc-3800-4429-986e-4cc208e95fc2"], &process)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var prepared deploymentmodel.Deployment
+	t.Run("import diagram", func(t *testing.T) {
+		body := bytes.NewBuffer(nil)
+		form := multipart.NewWriter(body)
+		for name, content := range map[string]string{"bpmn": process.Bpmn, "svg": process.Svg} {
+			w, err := form.CreateFormFile(name, name)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_, err = io.WriteString(w, content)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		err = form.Close()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		temp, err := bundleRequest("POST", apiUrl+"/deployment-imports/"+url.PathEscape(testHubId), form.FormDataContentType(), body.Bytes())
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result := model.DeploymentTransferResult{}
+		err = json.Unmarshal(temp, &result)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		//the first device-selection response offers no device for the task
+		if result.Deployed || len(result.Unmatched) != 1 || result.Unmatched[0].BpmnId != "Task_18tgni4" {
+			t.Errorf("%#v", result)
+		}
+		prepared = result.Deployment
+	})
+
+	t.Run("import bundle", func(t *testing.T) {
+		prepared.Name = "imported"
+		deploymentJson, err := json.Marshal(prepared)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		bundle, err := createZip(map[string][]byte{
+			controller.BundleManifestFile:   []byte(`{"format_version": 1, "source_hub_id": "staging"}`),
+			controller.BundleDeploymentFile: deploymentJson,
+			controller.BundleBpmnFile:       []byte(process.Bpmn),
+			controller.BundleSvgFile:        []byte(process.Svg),
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		temp, err := bundleRequest("POST", apiUrl+"/deployment-imports/"+url.PathEscape(testHubId), "application/zip", bundle)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result := model.DeploymentTransferResult{}
+		err = json.Unmarshal(temp, &result)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !result.Deployed || len(result.Unmatched) != 0 {
+			t.Errorf("%#v", result)
+			return
+		}
+		deployCalls := (*syncCalls)["/deployments/"+testHubId]
+		if len(deployCalls) != 1 {
+			t.Error(deployCalls)
+			return
+		}
+		deployed := deploymentmodel.Deployment{}
+		err = json.Unmarshal([]byte(deployCalls[0]), &deployed)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if deployed.Name != "imported" || deployed.Id == "" {
+			t.Error(deployed.Id, deployed.Name)
+		}
+	})
+
+	t.Run("reject invalid bundles", func(t *testing.T) {
+		//a few kilobytes that unpack to more than the maximal bundle size
+		bomb := bytes.NewBuffer(nil)
+		writer := zip.NewWriter(bomb)
+		for _, name := range []string{controller.BundleBpmnFile, controller.BundleSvgFile} {
+			w, err := writer.Create(name)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_, err = w.Write(make([]byte, 20<<20))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		err = writer.Close()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		duplicate := bytes.NewBuffer(nil)
+		writer = zip.NewWriter(duplicate)
+		for _, content := range []string{process.Bpmn, process.Bpmn} {
+			w, err := writer.Create(controller.BundleBpmnFile)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_, err = io.WriteString(w, content)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		err = writer.Close()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		unknown, err := createZip(map[string][]byte{
+			controller.BundleBpmnFile: []byte(process.Bpmn),
+			"other.bin":               []byte("x"),
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		for name, bundle := range map[string][]byte{
+			"maximal uncompressed size": bomb.Bytes(),
+			"duplicate file":            duplicate.Bytes(),
+			"unexpected file":           unknown,
+		} {
+			_, err = bundleRequest("POST", apiUrl+"/deployment-imports/"+url.PathEscape(testHubId), "application/zip", bundle)
+			if err == nil || !strings.HasPrefix(err.Error(), "400") || !strings.Contains(err.Error(), name) {
+				t.Error(name, err)
+			}
+		}
+	})
+}
+
+func bundleRequest(method string, endpoint string, contentType string, body []byte) (result []byte, err error) {
+	req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("Authorization", token)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	result, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return result, errors.New(strconv.Itoa(resp.StatusCode) + " " + string(result))
+	}
+	return result, nil
+}
+
+func unzip(archive []byte) (result map[string][]byte, err error) {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return result, err
+	}
+	result = map[string][]byte{}
+	for _, file := range reader.File {
+		f, err := file.Open()
+		if err != nil {
+			return result, err
+		}
+		result[file.Name], err = io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func createZip(files map[string][]byte) (result []byte, err error) {
+	buf := bytes.NewBuffer(nil)
+	writer := zip.NewWriter(buf)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		if err != nil {
+			return result, err
+		}
+		_, err = w.Write(content)
+		if err != nil {
+			return result, err
+		}
+	}
+	err = writer.Close()
+	return buf.Bytes(), err
+}
@@ -86,7 +86,7 @@ func TestController(t *testing.T) {
 		EnableDeviceGroupsForEvents: false,
 	}
 
-	ctrl, err := controller.New(config, processsync.New(config), devicerepo.Factory)
+	ctrl, err := controller.New(config, processsync.New(config), devicerepo.Factory, mocks.NewDatabaseMock())
 	if err != nil {
 		t.Error(err)
 		return
@@ -160,7 +160,11 @@ func TestController(t *testing.T) {
 	if !reflect.DeepEqual(*processesCalls, map[string][]string{"/processes/e32329bc-3800-4429-986e-4cc208e95fc2": {""}}) {
 		t.Error(*processesCalls)
 	}
-	expectedPermCalls := map[string][]string{"/check/devices?ids=urn%3Ainfai%3Ases%3Adevice%3Adc74369e-89bc-4c7a-ad38-aa4789ea0060&permissions=x&version=2": {""}}
+	expectedPermCalls := map[string][]string{
+		"/check/devices?ids=urn%3Ainfai%3Ases%3Adevice%3Adc74369e-89bc-4c7a-ad38-aa4789ea0060&permissions=x&version=2": {""},
+		"/check/hubs/urn:infai:ses:hub:114b6d26-5540-44e8-9aeb-234073a49995?permissions=r&version=2":                   {""}, //prepare
+		"/check/hubs/urn:infai:ses:hub:114b6d26-5540-44e8-9aeb-234073a49995?permissions=x&version=2":                   {""}, //deploy
+	}
 	if !reflect.DeepEqual(*permCalls, expectedPermCalls) {
 		t.Errorf("\n%#v\n%#v", *permCalls, expectedPermCalls)
 	}
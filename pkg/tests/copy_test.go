@@ -0,0 +1,159 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tests/mocks"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDeploymentCopy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	//the device-selection mock answers every copy with the next response of copy_selections.json
+	apiUrl, syncCalls, err := startTestApiWithResources(ctx, "resources/copy.json", "resources/copy_selections.json", mocks.NewDatabaseMock())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	targetHubId := "urn:infai:ses:hub:target"
+	copyUrl := apiUrl + "/deployments/" + url.PathEscape(testHubId) + "/d1/copy?target_hub=" + url.QueryEscape(targetHubId)
+	sourceDevice := "urn:infai:ses:device:dc74369e-89bc-4c7a-ad38-aa4789ea0060"
+	otherDevice := "urn:infai:ses:device:dc74369e-89bc-4c7a-ad38-aa4789ea0061"
+
+	//returns the selected device of the last deployment sent to the target hub
+	lastDeployedDevice := func(t *testing.T, count int) string {
+		deployCalls := (*syncCalls)["/deployments/"+targetHubId]
+		if len(deployCalls) != count {
+			t.Error(len(deployCalls), count)
+			return ""
+		}
+		deployed := deploymentmodel.Deployment{}
+		err := json.Unmarshal([]byte(deployCalls[count-1]), &deployed)
+		if err != nil {
+			t.Error(err)
+			return ""
+		}
+		if deployed.Id == "d1" || deployed.Name != "thermostat" || len(deployed.Elements) != 1 {
+			t.Error(deployed.Id, deployed.Name, len(deployed.Elements))
+			return ""
+		}
+		selection := deployed.Elements[0].Task.Selection
+		if selection.SelectedDeviceId == nil || selection.SelectedServiceId == nil {
+			t.Errorf("%#v", selection)
+			return ""
+		}
+		return *selection.SelectedDeviceId
+	}
+
+	t.Run("clone", func(t *testing.T) {
+		//the source device is available on the target hub under another name
+		result, err := jsonRequest[model.DeploymentTransferResult]("POST", copyUrl, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !result.Deployed || len(result.Unmatched) != 0 || result.State != model.DeploymentStatePendingSync {
+			t.Errorf("%#v", result)
+		}
+		if device := lastDeployedDevice(t, 1); device != sourceDevice {
+			t.Error(device)
+		}
+		if removeCalls := (*syncCalls)["/deployments/"+testHubId+"/c1"]; len(removeCalls) != 0 {
+			t.Error(removeCalls)
+		}
+	})
+
+	t.Run("match by name", func(t *testing.T) {
+		//the source device is missing on the target hub, one of two devices has the name of the source device
+		result, err := jsonRequest[model.DeploymentTransferResult]("POST", copyUrl, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !result.Deployed || len(result.Unmatched) != 0 {
+			t.Errorf("%#v", result)
+		}
+		if device := lastDeployedDevice(t, 2); device != otherDevice {
+			t.Error(device)
+		}
+	})
+
+	t.Run("move", func(t *testing.T) {
+		result, err := jsonRequest[model.DeploymentTransferResult]("POST", copyUrl+"&move=true", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !result.Deployed || len(result.Unmatched) != 0 {
+			t.Errorf("%#v", result)
+		}
+		//the only matching device is selected
+		if device := lastDeployedDevice(t, 3); device != otherDevice {
+			t.Error(device)
+		}
+		if removeCalls := (*syncCalls)["/deployments/"+testHubId+"/c1"]; len(removeCalls) != 1 {
+			t.Error(removeCalls)
+		}
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		result, err := jsonRequest[model.DeploymentTransferResult]("POST", copyUrl+"&move=true", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Deployed || len(result.Unmatched) != 1 || result.Unmatched[0].BpmnId != "Task_18tgni4" || !strings.HasPrefix(result.Unmatched[0].Reason, "ambiguous selection: 2 matching devices") {
+			t.Errorf("%#v", result)
+		}
+		//nothing is deployed and the source is kept
+		if deployCalls := (*syncCalls)["/deployments/"+targetHubId]; len(deployCalls) != 3 {
+			t.Error(len(deployCalls))
+		}
+		if removeCalls := (*syncCalls)["/deployments/"+testHubId+"/c1"]; len(removeCalls) != 1 {
+			t.Error(removeCalls)
+		}
+	})
+
+	t.Run("unmatched", func(t *testing.T) {
+		result, err := jsonRequest[model.DeploymentTransferResult]("POST", copyUrl, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Deployed || len(result.Unmatched) != 1 || result.Unmatched[0].Reason != "no matching device on target hub" {
+			t.Errorf("%#v", result)
+		}
+		if deployCalls := (*syncCalls)["/deployments/"+targetHubId]; len(deployCalls) != 3 {
+			t.Error(len(deployCalls))
+		}
+	})
+
+	t.Run("same hub", func(t *testing.T) {
+		_, err := jsonRequest[model.DeploymentTransferResult]("POST", apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d1/copy?target_hub="+url.QueryEscape(testHubId), nil)
+		if err == nil || !strings.HasPrefix(err.Error(), "400") {
+			t.Error(err)
+		}
+	})
+}
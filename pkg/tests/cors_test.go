@@ -0,0 +1,104 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/api/util"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCors(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusTeapot)
+	})
+
+	request := func(cors http.Handler, method string, origin string) *http.Response {
+		req := httptest.NewRequest(method, "/deployments/h1", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if method == http.MethodOptions {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+		recorder := httptest.NewRecorder()
+		cors.ServeHTTP(recorder, req)
+		return recorder.Result()
+	}
+
+	t.Run("allowlist", func(t *testing.T) {
+		cors := util.NewCors(handler, &configuration.ConfigStruct{
+			CorsAllowedOrigins:   []string{"https://ui.example.com", "https://*.senergy.example"},
+			CorsAllowedHeaders:   []string{"Authorization", "Content-Type"},
+			CorsAllowedMethods:   []string{http.MethodGet, http.MethodPost},
+			CorsAllowCredentials: true,
+			CorsMaxAge:           600,
+		})
+		for _, origin := range []string{"https://ui.example.com", "https://dev.senergy.example", "https://UI.example.com"} {
+			resp := request(cors, http.MethodGet, origin)
+			if resp.StatusCode != http.StatusTeapot || resp.Header.Get("Access-Control-Allow-Origin") != origin || resp.Header.Get("Access-Control-Allow-Credentials") != "true" {
+				t.Error(origin, resp.StatusCode, resp.Header)
+			}
+		}
+		resp := request(cors, http.MethodOptions, "https://dev.senergy.example")
+		if resp.StatusCode != http.StatusOK ||
+			resp.Header.Get("Access-Control-Allow-Methods") != "GET, POST" ||
+			resp.Header.Get("Access-Control-Allow-Headers") != "Authorization, Content-Type" ||
+			resp.Header.Get("Access-Control-Max-Age") != "600" {
+			t.Error(resp.StatusCode, resp.Header)
+		}
+		for _, origin := range []string{"https://evil.example", "https://ui.example.com.evil.example", "http://ui.example.com", "https://senergy.example"} {
+			for _, method := range []string{http.MethodGet, http.MethodOptions} {
+				resp = request(cors, method, origin)
+				for _, header := range []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Credentials", "Access-Control-Allow-Methods", "Access-Control-Allow-Headers", "Access-Control-Max-Age"} {
+					if resp.Header.Get(header) != "" {
+						t.Error(origin, method, header, resp.Header.Get(header))
+					}
+				}
+			}
+		}
+		resp = request(cors, http.MethodGet, "")
+		if resp.StatusCode != http.StatusTeapot || resp.Header.Get("Access-Control-Allow-Origin") != "" {
+			t.Error(resp.StatusCode, resp.Header)
+		}
+	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		cors := util.NewCors(handler, &configuration.ConfigStruct{
+			CorsAllowedOrigins:   []string{"*"},
+			CorsAllowCredentials: true,
+		})
+		resp := request(cors, http.MethodGet, "https://any.example")
+		if resp.Header.Get("Access-Control-Allow-Origin") != "*" || resp.Header.Get("Access-Control-Allow-Credentials") != "" {
+			t.Error(resp.Header)
+		}
+		resp = request(cors, http.MethodOptions, "https://any.example")
+		if resp.Header.Get("Access-Control-Allow-Methods") == "" || resp.Header.Get("Access-Control-Max-Age") != "" {
+			t.Error(resp.Header)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		cors := util.NewCors(handler, &configuration.ConfigStruct{})
+		resp := request(cors, http.MethodGet, "https://any.example")
+		if resp.StatusCode != http.StatusTeapot || resp.Header.Get("Access-Control-Allow-Origin") != "" {
+			t.Error(resp.StatusCode, resp.Header)
+		}
+	})
+}
@@ -0,0 +1,911 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/api"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/devicerepo"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/processsync"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tests/mocks"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testHubId = "urn:infai:ses:hub:114b6d26-5540-44e8-9aeb-234073a49995"
+
+func TestDeploymentRead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("list", func(t *testing.T) {
+		result, err := Jwtget[[]model.FogDeployment](token, apiUrl+"/deployments/"+url.PathEscape(testHubId))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := []model.FogDeployment{
+			{
+				Id:                   "d1",
+				Name:                 "first",
+				CamundaDeploymentIds: []string{"c1", "c2"},
+				IsPlaceholder:        true,
+				MarkedForDelete:      false,
+				SyncDate:             time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC),
+				State:                model.DeploymentStatePendingSync,
+			},
+			{
+				Id:                   "d2",
+				Name:                 "second",
+				CamundaDeploymentIds: []string{"c3"},
+				IsPlaceholder:        false,
+				MarkedForDelete:      true,
+				SyncDate:             time.Date(2023, 1, 3, 10, 0, 0, 0, time.UTC),
+				State:                model.DeploymentStatePendingDelete,
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("\n%#v\n%#v", result, expected)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		result, err := Jwtget[[]model.FogDeploymentInfo](token, apiUrl+"/deployments/"+url.PathEscape("urn:infai:ses:hub:2")+"/d3")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result) != 1 {
+			t.Error(result)
+			return
+		}
+		if result[0].CamundaDeploymentId != "c4" || result[0].Deployment.Id != "d3" || result[0].Deployment.Name != "third" || result[0].NetworkId != "urn:infai:ses:hub:2" || result[0].State != model.DeploymentStateActive {
+			t.Errorf("%#v", result[0])
+		}
+	})
+
+	t.Run("get unknown", func(t *testing.T) {
+		_, err := Jwtget[[]model.FogDeploymentInfo](token, apiUrl+"/deployments/"+url.PathEscape("urn:infai:ses:hub:empty")+"/unknown")
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestDeploymentState(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	//pending changes synced before 2100 are older than the stale duration
+	apiUrl, _, err := startTestApiWithResources(ctx, "resources/state.json", "resources/selections.json", mocks.NewDatabaseMock(), func(config *configuration.ConfigStruct, processSync *controller.ProcessSync) {
+		config.DeploymentStaleDuration = "24h"
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("list", func(t *testing.T) {
+		result, err := jsonRequest[[]model.FogDeployment](http.MethodGet, apiUrl+"/deployments/"+url.PathEscape(testHubId), nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		states := map[string]model.DeploymentState{}
+		for _, deployment := range result {
+			states[deployment.Id] = deployment.State
+		}
+		expected := map[string]model.DeploymentState{
+			"d1": model.DeploymentStateStale,
+			"d2": model.DeploymentStatePendingDelete,
+			"d3": model.DeploymentStateStale, //one of two camunda deployments is stale
+			"d4": model.DeploymentStateActive,
+		}
+		if !reflect.DeepEqual(states, expected) {
+			t.Errorf("\n%#v\n%#v", states, expected)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		result, err := jsonRequest[[]model.FogDeploymentInfo](http.MethodGet, apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d3", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result) != 2 || result[0].State != model.DeploymentStateActive || result[1].State != model.DeploymentStateStale {
+			t.Errorf("%#v", result)
+		}
+	})
+}
+
+func TestDeploymentUpdate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, syncCalls, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	prepared, err := Jwtget[deploymentmodel.Deployment](token, apiUrl+"/prepared-deployments/"+url.PathEscape(testHubId)+"/e32329bc-3800-4429-986e-4cc208e95fc2")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	deviceId := "urn:infai:ses:device:dc74369e-89bc-4c7a-ad38-aa4789ea0060"
+	serviceId := "urn:infai:ses:service:39415c76-93a3-4e8d-8740-d1a83c64bddc"
+	prepared.Id = ""
+	prepared.Elements[0].Task.Selection.SelectedDeviceId = &deviceId
+	prepared.Elements[0].Task.Selection.SelectedServiceId = &serviceId
+
+	resp, err := Jwtput(token, apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d1", prepared)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		temp, _ := io.ReadAll(resp.Body)
+		t.Error(resp.StatusCode, string(temp))
+		return
+	}
+	result := model.DeploymentWithState{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if result.Id != "d1" || result.State != model.DeploymentStatePendingSync {
+		t.Error(result.Id, result.State)
+	}
+
+	deployCalls := (*syncCalls)["/deployments/"+testHubId]
+	if len(deployCalls) != 1 {
+		t.Error(deployCalls)
+		return
+	}
+	deployed := deploymentmodel.Deployment{}
+	err = json.Unmarshal([]byte(deployCalls[0]), &deployed)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if deployed.Id != "d1" {
+		t.Error(deployed.Id)
+	}
+	for _, camundaId := range []string{"c1", "c2"} {
+		if len((*syncCalls)["/deployments/"+testHubId+"/"+camundaId]) != 1 {
+			t.Error("missing remove call for", camundaId)
+		}
+	}
+	if len((*syncCalls)["/deployments/"+testHubId+"/c3"]) != 0 {
+		t.Error("unexpected remove call for c3 of other deployment")
+	}
+}
+
+func TestDeploymentUpdateRollback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	//d1 consists of the camunda deployments c1 and c2, of which only c1 can be removed
+	apiUrl, syncCalls, err := startTestApi(ctx, "resources/update.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	targetHubId := "urn:infai:ses:hub:target"
+
+	prepared, err := jsonRequest[deploymentmodel.Deployment]("GET", apiUrl+"/prepared-deployments/"+url.PathEscape(testHubId)+"/e32329bc-3800-4429-986e-4cc208e95fc2", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	deviceId := "urn:infai:ses:device:dc74369e-89bc-4c7a-ad38-aa4789ea0060"
+	serviceId := "urn:infai:ses:service:39415c76-93a3-4e8d-8740-d1a83c64bddc"
+	prepared.Id = ""
+	prepared.Name = "updated"
+	prepared.Elements[0].Task.Selection.SelectedDeviceId = &deviceId
+	prepared.Elements[0].Task.Selection.SelectedServiceId = &serviceId
+
+	t.Run("failed remove", func(t *testing.T) {
+		deploymentUrl := apiUrl + "/deployments/" + url.PathEscape(testHubId) + "/d1"
+		_, err := jsonRequest[model.DeploymentWithState]("PUT", deploymentUrl, prepared)
+		if err == nil {
+			t.Error("expected error")
+			return
+		}
+		if len((*syncCalls)["/deployments/"+testHubId+"/c1"]) != 1 || len((*syncCalls)["/deployments/"+testHubId+"/c2"]) != 1 {
+			t.Error("missing remove calls")
+		}
+		//the updated model is deployed first, the removed c1 is redeployed by the rollback
+		deployCalls := (*syncCalls)["/deployments/"+testHubId]
+		if len(deployCalls) != 2 {
+			t.Error(len(deployCalls))
+			return
+		}
+		for i, name := range []string{"updated", "thermostat"} {
+			deployed := deploymentmodel.Deployment{}
+			err = json.Unmarshal([]byte(deployCalls[i]), &deployed)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if deployed.Id != "d1" || deployed.Name != name {
+				t.Error(i, deployed.Id, deployed.Name)
+			}
+		}
+		//only the model deployed before the update is recorded
+		versions, err := jsonRequest[[]model.DeploymentVersion]("GET", deploymentUrl+"/versions", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(versions) != 1 || versions[0].Deployment.Name != "thermostat" {
+			t.Errorf("%#v", versions)
+		}
+	})
+
+	t.Run("failed rollback", func(t *testing.T) {
+		apiUrl, _, err := startTestApiWithResources(ctx, "resources/update.json", "resources/selections.json", mocks.NewDatabaseMock(), func(config *configuration.ConfigStruct, processSync *controller.ProcessSync) {
+			*processSync = &failingRedeploySync{ProcessSync: *processSync}
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err = jsonRequest[model.DeploymentWithState]("PUT", apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d1", prepared)
+		if err == nil {
+			t.Error("expected error")
+			return
+		}
+		_, body, _ := strings.Cut(err.Error(), " ")
+		problem := model.Problem{}
+		err = json.Unmarshal([]byte(body), &problem)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if problem.Code != model.ErrorCodeRollbackFailed || !strings.Contains(problem.Detail, "unable to redeploy old camunda deployment c1") {
+			t.Errorf("%#v", problem)
+		}
+	})
+
+	t.Run("failed deploy", func(t *testing.T) {
+		//process-sync knows no deploy path for the target hub
+		deploymentUrl := apiUrl + "/deployments/" + url.PathEscape(targetHubId) + "/d2"
+		_, err := jsonRequest[model.DeploymentWithState]("PUT", deploymentUrl, prepared)
+		if err == nil {
+			t.Error("expected error")
+			return
+		}
+		if len((*syncCalls)["/deployments/"+targetHubId]) != 1 {
+			t.Error("missing deploy call")
+		}
+		if len((*syncCalls)["/deployments/"+targetHubId+"/c4"]) != 0 {
+			t.Error("unexpected remove call for c4")
+		}
+		versions, err := jsonRequest[[]model.DeploymentVersion]("GET", deploymentUrl+"/versions", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(versions) != 1 || versions[0].Deployment.Name != "unreachable" {
+			t.Errorf("%#v", versions)
+		}
+	})
+}
+
+// fails every deploy after the first one, e.g. the redeploy of a rollback after a failed update
+type failingRedeploySync struct {
+	controller.ProcessSync
+	mux     sync.Mutex
+	deploys int
+}
+
+func (this *failingRedeploySync) Deploy(ctx context.Context, token string, hubId string, deployment deploymentmodel.Deployment) error {
+	this.mux.Lock()
+	this.deploys++
+	deploys := this.deploys
+	this.mux.Unlock()
+	if deploys > 1 {
+		return errors.New("redeploy failed")
+	}
+	return this.ProcessSync.Deploy(ctx, token, hubId, deployment)
+}
+
+func TestDeploymentPatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, syncCalls, err := startTestApi(ctx, "resources/update.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	deploymentUrl := apiUrl + "/deployments/" + url.PathEscape(testHubId) + "/d3"
+
+	t.Run("patch", func(t *testing.T) {
+		patch := []map[string]interface{}{
+			{"op": "replace", "path": "/name", "value": "patched"},
+			{"op": "replace", "path": "/elements/0/task/parameter/inputs", "value": "21"},
+		}
+		result, err := jsonRequest[model.DeploymentWithState]("PATCH", deploymentUrl, patch)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Id != "d3" || result.Name != "patched" || result.State != model.DeploymentStatePendingSync {
+			t.Error(result.Id, result.Name, result.State)
+		}
+		deployCalls := (*syncCalls)["/deployments/"+testHubId]
+		if len(deployCalls) != 1 {
+			t.Error(len(deployCalls))
+			return
+		}
+		deployed := deploymentmodel.Deployment{}
+		err = json.Unmarshal([]byte(deployCalls[0]), &deployed)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if deployed.Id != "d3" || deployed.Name != "patched" || deployed.Elements[0].Task.Parameter["inputs"] != "21" {
+			t.Error(deployed.Id, deployed.Name, deployed.Elements[0].Task.Parameter)
+		}
+		if len((*syncCalls)["/deployments/"+testHubId+"/c3"]) != 1 {
+			t.Error("missing remove call for c3")
+		}
+	})
+
+	t.Run("invalid patch", func(t *testing.T) {
+		_, err := jsonRequest[model.DeploymentWithState]("PATCH", deploymentUrl, []map[string]interface{}{{"op": "replace", "path": "/unknown/field", "value": 1}})
+		if err == nil || !strings.HasPrefix(err.Error(), "400") {
+			t.Error(err)
+		}
+		if len((*syncCalls)["/deployments/"+testHubId]) != 1 {
+			t.Error("unexpected deploy call")
+		}
+	})
+}
+
+func TestDeploymentVersions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, syncCalls, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	deploymentUrl := apiUrl + "/deployments/" + url.PathEscape(testHubId) + "/d1"
+
+	versions, err := jsonRequest[[]model.DeploymentVersion]("GET", deploymentUrl+"/versions", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(versions) != 0 {
+		t.Error(versions)
+		return
+	}
+
+	prepared, err := jsonRequest[deploymentmodel.Deployment]("GET", apiUrl+"/prepared-deployments/"+url.PathEscape(testHubId)+"/e32329bc-3800-4429-986e-4cc208e95fc2", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	deviceId := "urn:infai:ses:device:dc74369e-89bc-4c7a-ad38-aa4789ea0060"
+	serviceId := "urn:infai:ses:service:39415c76-93a3-4e8d-8740-d1a83c64bddc"
+	prepared.Id = ""
+	prepared.Name = "updated"
+	prepared.Elements[0].Task.Selection.SelectedDeviceId = &deviceId
+	prepared.Elements[0].Task.Selection.SelectedServiceId = &serviceId
+
+	_, err = jsonRequest[model.DeploymentWithState]("PUT", deploymentUrl, prepared)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	prepared.Name = "second"
+	_, err = jsonRequest[model.DeploymentWithState]("PUT", deploymentUrl, prepared)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	//the model deployed before the first update is recorded as first version
+	versions, err = jsonRequest[[]model.DeploymentVersion]("GET", deploymentUrl+"/versions", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(versions) != 3 {
+		t.Errorf("%#v", versions)
+		return
+	}
+	for i, name := range []string{"first", "updated", "second"} {
+		if versions[i].Version != i+1 || versions[i].Deployment.Name != name || versions[i].HubId != testHubId || versions[i].DeploymentId != "d1" {
+			t.Errorf("%#v", versions[i])
+		}
+	}
+
+	version, err := jsonRequest[model.DeploymentVersion]("GET", deploymentUrl+"/versions/2", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if version.Deployment.Name != "updated" {
+		t.Error(version.Deployment.Name)
+	}
+	_, err = jsonRequest[model.DeploymentVersion]("GET", deploymentUrl+"/versions/42", nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "404") {
+		t.Error(err)
+	}
+
+	_, err = jsonRequest[model.DeploymentWithState]("POST", deploymentUrl+"/versions/2/rollback", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	deployCalls := (*syncCalls)["/deployments/"+testHubId]
+	if len(deployCalls) != 3 {
+		t.Error(len(deployCalls))
+		return
+	}
+	deployed := deploymentmodel.Deployment{}
+	err = json.Unmarshal([]byte(deployCalls[2]), &deployed)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if deployed.Id != "d1" || deployed.Name != version.Deployment.Name {
+		t.Error(deployed.Id, deployed.Name)
+	}
+
+	versions, err = jsonRequest[[]model.DeploymentVersion]("GET", deploymentUrl+"/versions", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(versions) != 4 || versions[3].Deployment.Name != "updated" {
+		t.Errorf("%#v", versions)
+	}
+}
+
+func TestDeploymentValidate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, syncCalls, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	prepared, err := Jwtget[deploymentmodel.Deployment](token, apiUrl+"/prepared-deployments/"+url.PathEscape(testHubId)+"/e32329bc-3800-4429-986e-4cc208e95fc2")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	validate := func(deployment deploymentmodel.Deployment) (result model.ValidationResult, err error) {
+		body, err := json.Marshal(deployment)
+		if err != nil {
+			return result, err
+		}
+		req, err := http.NewRequest(http.MethodPost, apiUrl+"/deployment-validations/"+url.PathEscape(testHubId), bytes.NewReader(body))
+		if err != nil {
+			return result, err
+		}
+		req.Header.Set("Authorization", token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return result, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			return result, errors.New(string(temp))
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result, err
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := prepared
+		invalid.Name = ""
+		result, err := validate(invalid)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Valid || len(result.Problems) != 2 {
+			t.Errorf("%#v", result)
+			return
+		}
+		if result.Problems[0].BpmnId != "" || result.Problems[1].BpmnId != prepared.Elements[0].BpmnId {
+			t.Errorf("%#v", result.Problems)
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		deviceId := "urn:infai:ses:device:dc74369e-89bc-4c7a-ad38-aa4789ea0060"
+		serviceId := "urn:infai:ses:service:39415c76-93a3-4e8d-8740-d1a83c64bddc"
+		valid := prepared
+		valid.Elements = []deploymentmodel.Element{prepared.Elements[0]}
+		task := *valid.Elements[0].Task
+		task.Selection.SelectedDeviceId = &deviceId
+		task.Selection.SelectedServiceId = &serviceId
+		valid.Elements[0].Task = &task
+		result, err := validate(valid)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !result.Valid || len(result.Problems) != 0 || result.Deployment == nil || result.Deployment.Diagram.XmlDeployed == "" {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	if len((*syncCalls)["/deployments/"+testHubId]) != 0 {
+		t.Error("validation should not deploy", *syncCalls)
+	}
+}
+
+func TestDeploymentInstances(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, syncCalls, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	deploymentUrl := apiUrl + "/deployments/" + url.PathEscape(testHubId)
+
+	t.Run("list", func(t *testing.T) {
+		result, err := Jwtget[[]model.ProcessInstance](token, deploymentUrl+"/d1/instances")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result) != 2 || result[0].Id != "i1" || result[1].Id != "i3" {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("history", func(t *testing.T) {
+		result, err := Jwtget[[]model.HistoricProcessInstance](token, deploymentUrl+"/d2/history")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result) != 1 || result[0].Id != "h2" {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		_, err := Jwtdelete[bool](token, deploymentUrl+"/d1/instances/i1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len((*syncCalls)["/process-instances/"+testHubId+"/i1"]) != 1 {
+			t.Error(*syncCalls)
+		}
+	})
+
+	t.Run("stop instance of other deployment", func(t *testing.T) {
+		_, err := Jwtdelete[bool](token, deploymentUrl+"/d1/instances/i2")
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("delete history", func(t *testing.T) {
+		_, err := Jwtdelete[bool](token, deploymentUrl+"/d1/history/h1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len((*syncCalls)["/history/process-instances/"+testHubId+"/h1"]) != 1 {
+			t.Error(*syncCalls)
+		}
+	})
+}
+
+func TestDeploymentIncidents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	result, err := Jwtget[[]model.FogIncident](token, apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d1/incidents")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result) != 2 {
+		t.Errorf("%#v", result)
+		return
+	}
+	if result[0].Id != "inc1" || result[0].CamundaDeploymentId != "c1" || result[0].BpmnId != "Task_1" || result[0].ErrorMessage != "device not reachable" {
+		t.Errorf("%#v", result[0])
+	}
+	if result[1].Id != "inc3" || result[1].CamundaDeploymentId != "c2" || result[1].BpmnId != "Task_3" || result[1].ErrorMessage != "unknown service" {
+		t.Errorf("%#v", result[1])
+	}
+}
+
+func TestDeploymentStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, syncCalls, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	start := func(path string, body string) (result model.StartResult, err error) {
+		req, err := http.NewRequest(http.MethodPost, apiUrl+"/deployments/"+url.PathEscape(testHubId)+path, bytes.NewBufferString(body))
+		if err != nil {
+			return result, err
+		}
+		req.Header.Set("Authorization", token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return result, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			return result, errors.New(strconv.Itoa(resp.StatusCode) + " " + string(temp))
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result, err
+	}
+
+	//metadata.json only knows the start paths with the expected query
+	t.Run("typed", func(t *testing.T) {
+		result, err := start("/d2/start?business_key=bk1", `{"b":{"c":"x"},"n":1234567890123456789,"s":"str"}`)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len((*syncCalls)["/deployments/"+testHubId+"/c3/start"]) != 1 {
+			t.Error(*syncCalls)
+		}
+		expected := model.StartResult{
+			BusinessKey: "bk1",
+			Instances:   []model.StartedInstance{{CamundaDeploymentId: "c3", ProcessInstanceId: "inst1"}},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("\n%#v\n%#v", result, expected)
+		}
+	})
+
+	t.Run("coerced", func(t *testing.T) {
+		result, err := start("/d1/start?business_key=bk2", `{"count":"7","name":"x"}`)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := model.StartResult{
+			BusinessKey: "bk2",
+			Instances: []model.StartedInstance{
+				{CamundaDeploymentId: "c1", ProcessInstanceId: "inst2"},
+				{CamundaDeploymentId: "c2", ProcessInstanceId: "inst3"},
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("\n%#v\n%#v", result, expected)
+		}
+	})
+
+	//query values are passed as sent to the declared type; 1.50 must not be parsed as number
+	t.Run("query", func(t *testing.T) {
+		result, err := jsonRequest[model.StartResult](http.MethodGet, apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d1/start?business_key=bk4&count=1&name=1.50", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := model.StartResult{
+			BusinessKey: "bk4",
+			Instances: []model.StartedInstance{
+				{CamundaDeploymentId: "c1", ProcessInstanceId: "inst4"},
+				{CamundaDeploymentId: "c2", ProcessInstanceId: "inst5"},
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("\n%#v\n%#v", result, expected)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := start("/d1/start", `{"count":"abc","unknown":1}`)
+		if err == nil {
+			t.Error("expected error")
+			return
+		}
+		for _, expected := range []string{"400", "unknown parameter 'unknown'", "missing parameter 'name'", "parameter 'count'"} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Error(expected, err)
+			}
+		}
+	})
+
+	//Integer parameters are int32, Long parameters int64
+	t.Run("out of range", func(t *testing.T) {
+		_, err := start("/d1/start", `{"count":2147483648,"name":"x"}`)
+		if err == nil || !strings.HasPrefix(err.Error(), "400") || !strings.Contains(err.Error(), "parameter 'count': out of range for Integer") {
+			t.Error(err)
+		}
+		_, err = jsonRequest[model.StartResult](http.MethodGet, apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d1/start?count=-2147483649&name=x", nil)
+		if err == nil || !strings.HasPrefix(err.Error(), "400") || !strings.Contains(err.Error(), "parameter 'count': out of range for Integer") {
+			t.Error(err)
+		}
+		_, err = start("/d2/start", `{"b":{"c":"x"},"n":9223372036854775808,"s":"str"}`)
+		if err == nil || !strings.HasPrefix(err.Error(), "400") || !strings.Contains(err.Error(), "parameter 'n': out of range for Long") {
+			t.Error(err)
+		}
+	})
+	//process-sync responds to the start of c7 with 'true' and fails to start c8
+	t.Run("partial", func(t *testing.T) {
+		_, err := start("/d5/start?business_key=bk5", "")
+		if err == nil {
+			t.Error("expected error")
+			return
+		}
+		status, body, _ := strings.Cut(err.Error(), " ")
+		problem := model.Problem{}
+		err = json.Unmarshal([]byte(body), &problem)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if status != "500" || problem.Upstream != "process-sync" {
+			t.Errorf("%v %#v", status, problem)
+		}
+		expected := []model.StartedInstance{{CamundaDeploymentId: "c7"}}
+		if !reflect.DeepEqual(problem.StartedInstances, expected) {
+			t.Errorf("\n%#v\n%#v", problem.StartedInstances, expected)
+		}
+		if !strings.Contains(body, `"started_instances":[{"camunda_deployment_id":"c7"}]`) {
+			t.Error(body)
+		}
+	})
+}
+
+func TestDeploymentStartParameters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	result, err := Jwtget[map[string]interface{}](token, apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d1/start-parameters")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"count":  map[string]interface{}{"title": "count", "type": "integer", "default": float64(5)},
+			"name":   map[string]interface{}{"title": "name", "type": "string"},
+			"config": map[string]interface{}{"title": "config", "default": map[string]interface{}{"a": float64(1)}},
+		},
+		"required":             []interface{}{"name"},
+		"additionalProperties": false,
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("\n%#v\n%#v", result, expected)
+	}
+}
+
+func startTestApi(ctx context.Context, syncResources string) (apiUrl string, syncCalls *map[string][]string, err error) {
+	return startTestApiWithDb(ctx, syncResources, mocks.NewDatabaseMock())
+}
+
+func startTestApiWithDb(ctx context.Context, syncResources string, db controller.Database) (apiUrl string, syncCalls *map[string][]string, err error) {
+	return startTestApiWithResources(ctx, syncResources, "resources/selections.json", db)
+}
+
+// testApiOption may adjust the config or replace the process-sync client before the api is started
+type testApiOption func(config *configuration.ConfigStruct, processSync *controller.ProcessSync)
+
+func startTestApiWithResources(ctx context.Context, syncResources string, selectionResources string, db controller.Database, options ...testApiOption) (apiUrl string, syncCalls *map[string][]string, err error) {
+	permUrl, _ := mocks.NewPermMock(ctx)
+	deviceRepoUrl, _, err := mocks.NewStatelessRepoMock(ctx, "resources/devicerepository.json")
+	if err != nil {
+		return apiUrl, syncCalls, err
+	}
+	syncUrl, syncCalls, err := mocks.NewStatelessRepoMock(ctx, syncResources)
+	if err != nil {
+		return apiUrl, syncCalls, err
+	}
+	processesUrl, _, err := mocks.NewStatelessRepoMock(ctx, "resources/processes.json")
+	if err != nil {
+		return apiUrl, syncCalls, err
+	}
+	selectionsUrl, _, err := mocks.NewStatefulRequestMock(ctx, selectionResources)
+	if err != nil {
+		return apiUrl, syncCalls, err
+	}
+	freePort, err := GetFreePort()
+	if err != nil {
+		return apiUrl, syncCalls, err
+	}
+	config := &configuration.ConfigStruct{
+		ApiPort:                     strconv.Itoa(freePort),
+		DeviceRepoUrl:               deviceRepoUrl,
+		ProcessRepoUrl:              processesUrl,
+		PermissionsV2Url:            permUrl,
+		DeviceSelectionUrl:          selectionsUrl,
+		Debug:                       true,
+		NotificationUrl:             "http://notification:8080",
+		ProcessSyncUrl:              syncUrl,
+		EnableDeviceGroupsForTasks:  true,
+		EnableDeviceGroupsForEvents: false,
+		ScheduleCheckInterval:       "100ms",
+		AuthEndpoint:                mocks.NewKeycloakMock(ctx, token),
+	}
+	var processSync controller.ProcessSync = processsync.New(config)
+	for _, option := range options {
+		option(config, &processSync)
+	}
+	ctrl, err := controller.New(config, processSync, devicerepo.Factory, db)
+	if err != nil {
+		return apiUrl, syncCalls, err
+	}
+	ctrl.StartScheduler(ctx)
+	err = api.Start(config, ctx, ctrl)
+	if err != nil {
+		return apiUrl, syncCalls, err
+	}
+	time.Sleep(100 * time.Millisecond)
+	return "http://localhost:" + config.ApiPort, syncCalls, nil
+}
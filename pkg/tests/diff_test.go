@@ -0,0 +1,179 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deviceselectionmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffDeployments(t *testing.T) {
+	old := deploymentmodel.Deployment{
+		Id:               "a",
+		Name:             "heating",
+		IncidentHandling: &deploymentmodel.IncidentHandling{Restart: false, Notify: true},
+		Elements: []deploymentmodel.Element{
+			{
+				BpmnId: "task",
+				Name:   "set temperature",
+				Task: &deploymentmodel.Task{
+					Parameter: map[string]string{"inputs": "21", "unit": "celsius"},
+					Selection: deploymentmodel.Selection{
+						SelectedDeviceId:  strptr("d1"),
+						SelectedServiceId: strptr("s1"),
+						SelectedPath:      &deviceselectionmodel.PathOption{Path: "value"},
+					},
+				},
+			},
+			{
+				BpmnId:    "timer",
+				TimeEvent: &deploymentmodel.TimeEvent{Type: "timeDuration", Time: "PT1H"},
+			},
+			{
+				BpmnId:       "removed",
+				Name:         "notify",
+				Notification: &deploymentmodel.Notification{Title: "t", Message: "m"},
+			},
+			{
+				BpmnId: "event",
+				ConditionalEvent: &deploymentmodel.ConditionalEvent{
+					Script:    "x > 1",
+					Variables: map[string]string{"a": "b"},
+					Selection: deploymentmodel.Selection{SelectedDeviceGroupId: strptr("g1")},
+				},
+			},
+		},
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		other := old
+		other.Id = "b"
+		other.Diagram.XmlDeployed = "<xml/>"
+		result := controller.DiffDeployments(old, other)
+		if result.Changed || len(result.Changes) != 0 {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		updated := deploymentmodel.Deployment{
+			Id:               "a",
+			Name:             "heating",
+			IncidentHandling: &deploymentmodel.IncidentHandling{Restart: true, Notify: true},
+			Elements: []deploymentmodel.Element{
+				{
+					BpmnId: "task",
+					Name:   "set temperature",
+					Task: &deploymentmodel.Task{
+						Parameter: map[string]string{"inputs": "22", "mode": "eco"},
+						Selection: deploymentmodel.Selection{
+							SelectedDeviceId:  strptr("d2"),
+							SelectedServiceId: strptr("s1"),
+						},
+					},
+				},
+				{
+					BpmnId:    "timer",
+					TimeEvent: &deploymentmodel.TimeEvent{Type: "timeDuration", Time: "PT2H"},
+				},
+				{
+					BpmnId: "event",
+					ConditionalEvent: &deploymentmodel.ConditionalEvent{
+						Script:    "x > 2",
+						Variables: map[string]string{"a": "b"},
+						Selection: deploymentmodel.Selection{SelectedDeviceGroupId: strptr("g1")},
+					},
+				},
+				{
+					BpmnId: "added",
+					Name:   "new task",
+					Task:   &deploymentmodel.Task{},
+				},
+			},
+		}
+		result := controller.DiffDeployments(old, updated)
+		expected := []model.DeploymentChange{
+			{Field: "incident_handling.restart", Old: false, New: true},
+			{BpmnId: "task", Field: "task.parameter.inputs", Old: "21", New: "22"},
+			{BpmnId: "task", Field: "task.parameter.mode", Old: nil, New: "eco"},
+			{BpmnId: "task", Field: "task.parameter.unit", Old: "celsius", New: nil},
+			{BpmnId: "task", Field: "task.selection.selected_device_id", Old: "d1", New: "d2"},
+			{BpmnId: "task", Field: "task.selection.selected_path", Old: "value", New: nil},
+			{BpmnId: "timer", Field: "time_event.time", Old: "PT1H", New: "PT2H"},
+			{BpmnId: "event", Field: "conditional_event.script", Old: "x > 1", New: "x > 2"},
+			{BpmnId: "added", Field: "element", Old: nil, New: "new task"},
+			{BpmnId: "removed", Field: "element", Old: "notify", New: nil},
+		}
+		if !result.Changed || !reflect.DeepEqual(result.Changes, expected) {
+			t.Errorf("\n%#v\n%#v", result.Changes, expected)
+		}
+	})
+
+	t.Run("missing incident handling", func(t *testing.T) {
+		other := old
+		other.IncidentHandling = nil
+		result := controller.DiffDeployments(old, other)
+		expected := []model.DeploymentChange{{Field: "incident_handling.notify", Old: true, New: false}}
+		if !reflect.DeepEqual(result.Changes, expected) {
+			t.Errorf("%#v", result.Changes)
+		}
+	})
+}
+
+func TestDeploymentDiffApi(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	diffUrl := apiUrl + "/deployments/" + url.PathEscape(testHubId) + "/d1/diff"
+
+	result, err := jsonRequest[model.DeploymentDiff]("GET", diffUrl, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if result.Changed {
+		t.Errorf("%#v", result)
+	}
+
+	proposed := deploymentmodel.Deployment{Id: "d1", Name: "second", Executable: true}
+	result, err = jsonRequest[model.DeploymentDiff]("POST", diffUrl, proposed)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := []model.DeploymentChange{{Field: "name", Old: "first", New: "second"}}
+	if !reflect.DeepEqual(result.Changes, expected) {
+		t.Errorf("%#v", result.Changes)
+	}
+
+	_, err = jsonRequest[model.DeploymentDiff]("GET", diffUrl+"?from_version=1", nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "404") {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/api/util"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestErrorResponses(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	deploymentsUrl := apiUrl + "/deployments/" + url.PathEscape(testHubId)
+
+	problemRequest := func(method string, endpoint string, body string, requestId string) (result model.Problem, resp *http.Response, err error) {
+		req, err := http.NewRequest(method, endpoint, bytes.NewBufferString(body))
+		if err != nil {
+			return result, resp, err
+		}
+		req.Header.Set("Authorization", token)
+		if requestId != "" {
+			req.Header.Set(util.RequestIdHeader, requestId)
+		}
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			return result, resp, err
+		}
+		defer resp.Body.Close()
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		return result, resp, err
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		result, resp, err := problemRequest(http.MethodGet, apiUrl+"/unknown", "", "")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/problem+json") {
+			t.Error(resp.Header.Get("Content-Type"))
+		}
+		if resp.StatusCode != http.StatusNotFound || result.Status != http.StatusNotFound || result.Code != model.ErrorCodeNotFound {
+			t.Errorf("%v %#v", resp.StatusCode, result)
+		}
+		if result.RequestId == "" || result.RequestId != resp.Header.Get(util.RequestIdHeader) {
+			t.Errorf("%#v %v", result, resp.Header.Get(util.RequestIdHeader))
+		}
+	})
+
+	t.Run("invalid start parameters", func(t *testing.T) {
+		result, resp, err := problemRequest(http.MethodPost, deploymentsUrl+"/d1/start", `{"count":"abc"}`, "test-request")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusBadRequest || result.Code != model.ErrorCodeInvalidStartParameters || result.RequestId != "test-request" {
+			t.Errorf("%v %#v", resp.StatusCode, result)
+		}
+		if !strings.Contains(result.Detail, "parameter 'count'") {
+			t.Error(result.Detail)
+		}
+	})
+
+	t.Run("message event", func(t *testing.T) {
+		groupId := "group"
+		deployment := deploymentmodel.Deployment{
+			Version: deploymentmodel.CurrentVersion,
+			Name:    "test",
+			Diagram: deploymentmodel.Diagram{XmlRaw: "<xml/>"},
+			Elements: []deploymentmodel.Element{{
+				BpmnId:       "Event_1",
+				MessageEvent: &deploymentmodel.MessageEvent{Selection: deploymentmodel.Selection{SelectedDeviceGroupId: &groupId}},
+			}},
+		}
+		body, err := json.Marshal(deployment)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result, err := jsonRequest[model.ValidationResult](http.MethodPost, apiUrl+"/deployment-validations/"+url.PathEscape(testHubId), json.RawMessage(body))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Valid || len(result.Problems) != 1 {
+			t.Errorf("%#v", result)
+			return
+		}
+		if result.Problems[0].BpmnId != "Event_1" || result.Problems[0].Code != model.ErrorCodeMessageEventsUnsupported {
+			t.Errorf("%#v", result.Problems[0])
+		}
+	})
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/devicerepo"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/processsync"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tests/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	resp, err := http.Get(apiUrl + "/health/live")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Error(resp.StatusCode)
+	}
+
+	resp, err = http.Get(apiUrl + "/health/ready")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Body.Close()
+	result := model.ReadinessReport{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if resp.StatusCode != http.StatusOK || !result.Ready || len(result.Dependencies) != 5 {
+		t.Errorf("%v %#v", resp.StatusCode, result)
+	}
+}
+
+func TestReadinessUnreachableDependency(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reachable := httptest.NewServer(http.NotFoundHandler())
+	defer reachable.Close()
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	config := &configuration.ConfigStruct{
+		DeviceRepoUrl:      reachable.URL,
+		ProcessRepoUrl:     reachable.URL,
+		PermissionsV2Url:   reachable.URL,
+		DeviceSelectionUrl: reachable.URL,
+		ProcessSyncUrl:     unreachable.URL,
+	}
+	ctrl, err := controller.New(config, processsync.New(config), devicerepo.Factory, mocks.NewDatabaseMock())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	result := ctrl.CheckReadiness(ctx)
+	if result.Ready || len(result.Dependencies) != 5 {
+		t.Errorf("%#v", result)
+		return
+	}
+	for _, dependency := range result.Dependencies {
+		if dependency.Name == "process-sync" {
+			if dependency.Ready || dependency.Error == "" {
+				t.Errorf("%#v", dependency)
+			}
+		} else if !dependency.Ready || dependency.Status != http.StatusNotFound {
+			t.Errorf("%#v", dependency)
+		}
+	}
+}
+
+func TestReadinessUnavailableDependency(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reachable := httptest.NewServer(http.NotFoundHandler())
+	defer reachable.Close()
+	unavailable := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+
+	config := &configuration.ConfigStruct{
+		DeviceRepoUrl:      reachable.URL,
+		ProcessRepoUrl:     reachable.URL,
+		PermissionsV2Url:   reachable.URL,
+		DeviceSelectionUrl: reachable.URL,
+		ProcessSyncUrl:     unavailable.URL,
+	}
+	ctrl, err := controller.New(config, processsync.New(config), devicerepo.Factory, mocks.NewDatabaseMock())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	result := ctrl.CheckReadiness(ctx)
+	if result.Ready || len(result.Dependencies) != 5 {
+		t.Errorf("%#v", result)
+		return
+	}
+	for _, dependency := range result.Dependencies {
+		if dependency.Name == "process-sync" {
+			if dependency.Ready || dependency.Status != http.StatusServiceUnavailable || dependency.Error == "" {
+				t.Errorf("%#v", dependency)
+			}
+		} else if !dependency.Ready {
+			t.Errorf("%#v", dependency)
+		}
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/controller"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/devicerepo"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/processsync"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tests/docker"
 	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tests/mocks"
@@ -134,7 +135,7 @@ func TestIntegration(t *testing.T) {
 		EnableDeviceGroupsForEvents: false,
 	}
 
-	ctrl, err := controller.New(config, processsync.New(config), devicerepo.Factory)
+	ctrl, err := controller.New(config, processsync.New(config), devicerepo.Factory, mocks.NewDatabaseMock())
 	if err != nil {
 		t.Error(err)
 		return
@@ -369,7 +370,7 @@ func TestIntegration(t *testing.T) {
 	})
 
 	t.Run("start", func(t *testing.T) {
-		_, err = Jwtget[bool](AdminJwt, "http://localhost:"+strconv.Itoa(freePort)+"/deployments/"+url.PathEscape("urn:infai:ses:hubs:h1")+"/"+url.PathEscape(depl.Id)+"/start")
+		_, err = Jwtget[model.StartResult](AdminJwt, "http://localhost:"+strconv.Itoa(freePort)+"/deployments/"+url.PathEscape("urn:infai:ses:hubs:h1")+"/"+url.PathEscape(depl.Id)+"/start")
 		if err != nil {
 			t.Error(err)
 			return
@@ -391,11 +392,15 @@ func TestIntegration(t *testing.T) {
 	})
 
 	t.Run("delete", func(t *testing.T) {
-		_, err = Jwtdelete[bool](AdminJwt, "http://localhost:"+strconv.Itoa(freePort)+"/deployments/"+url.PathEscape("urn:infai:ses:hubs:h1")+"/"+url.PathEscape(depl.Id))
+		result, err := Jwtdelete[model.DeploymentStateInfo](AdminJwt, "http://localhost:"+strconv.Itoa(freePort)+"/deployments/"+url.PathEscape("urn:infai:ses:hubs:h1")+"/"+url.PathEscape(depl.Id))
 		if err != nil {
 			t.Error(err)
 			return
 		}
+		if result.State != model.DeploymentStatePendingDelete {
+			t.Error(result)
+			return
+		}
 	})
 
 	time.Sleep(2 * time.Second)
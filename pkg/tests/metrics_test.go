@@ -0,0 +1,76 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = jsonRequest[deploymentmodel.Deployment]("GET", apiUrl+"/prepared-deployments/"+url.PathEscape(testHubId)+"/e32329bc-3800-4429-986e-4cc208e95fc2", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = jsonRequest[[]interface{}]("GET", apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d1", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	resp, err := http.Get(apiUrl + "/metrics")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Body.Close()
+	temp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	result := string(temp)
+	for _, expected := range []string{
+		`process_fog_deployment_api_requests_total{method="GET",route="/prepared-deployments/:hubId/:modelId",status="200"}`,
+		`process_fog_deployment_api_requests_total{method="GET",route="/deployments/:hubId/:id",status="200"}`,
+		`process_fog_deployment_api_request_duration_seconds_count{method="GET",route="/deployments/:hubId/:id"}`,
+		`process_fog_deployment_prepare_duration_seconds_count`,
+		`process_fog_deployment_upstream_requests_total{operation="get-hub",status="200",upstream="device-repository"}`,
+		`process_fog_deployment_upstream_requests_total{operation="bulk-selectables-v2",status="200",upstream="device-selection"}`,
+		`process_fog_deployment_upstream_requests_total{operation="metadata",status="200",upstream="process-sync"}`,
+		`process_fog_deployment_upstream_request_duration_seconds_count{operation="metadata",upstream="process-sync"}`,
+	} {
+		if !strings.Contains(result, expected) {
+			t.Error("missing", expected)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mocks
+
+import (
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"sort"
+	"sync"
+	"time"
+)
+
+type DatabaseMock struct {
+	mux       sync.Mutex
+	schedules map[string]model.Schedule
+	versions  []model.DeploymentVersion
+	templates map[string]model.DeploymentTemplate
+}
+
+func NewDatabaseMock() *DatabaseMock {
+	return &DatabaseMock{schedules: map[string]model.Schedule{}, versions: []model.DeploymentVersion{}, templates: map[string]model.DeploymentTemplate{}}
+}
+
+func (this *DatabaseMock) ListSchedules(userId string, hubId string) (result []model.Schedule, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result = []model.Schedule{}
+	for _, schedule := range this.schedules {
+		if schedule.UserId == userId && schedule.HubId == hubId {
+			result = append(result, schedule)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+	return result, nil
+}
+
+func (this *DatabaseMock) GetSchedule(id string) (result model.Schedule, exists bool, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result, exists = this.schedules[id]
+	return result, exists, nil
+}
+
+func (this *DatabaseMock) SetSchedule(schedule model.Schedule) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.schedules[schedule.Id] = schedule
+	return nil
+}
+
+func (this *DatabaseMock) RemoveSchedule(id string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	delete(this.schedules, id)
+	return nil
+}
+
+func (this *DatabaseMock) ListDueSchedules(now time.Time) (result []model.Schedule, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result = []model.Schedule{}
+	for _, schedule := range this.schedules {
+		if !schedule.Disabled && !schedule.NextRun.After(now) {
+			result = append(result, schedule)
+		}
+	}
+	return result, nil
+}
+
+func (this *DatabaseMock) ClaimScheduleRun(schedule model.Schedule, nextRun time.Time, lastRun time.Time) (claimed bool, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	current, ok := this.schedules[schedule.Id]
+	if !ok || !current.NextRun.Equal(schedule.NextRun) {
+		return false, nil
+	}
+	current.NextRun = nextRun
+	current.LastRun = &lastRun
+	this.schedules[schedule.Id] = current
+	return true, nil
+}
+
+func (this *DatabaseMock) SetScheduleRunResult(id string, lastError string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	current, ok := this.schedules[id]
+	if ok {
+		current.LastError = lastError
+		this.schedules[id] = current
+	}
+	return nil
+}
+
+func (this *DatabaseMock) AddDeploymentVersion(version model.DeploymentVersion) (result model.DeploymentVersion, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	version.Version = 1
+	for _, v := range this.versions {
+		if v.HubId == version.HubId && v.DeploymentId == version.DeploymentId && v.Version >= version.Version {
+			version.Version = v.Version + 1
+		}
+	}
+	this.versions = append(this.versions, version)
+	return version, nil
+}
+
+func (this *DatabaseMock) ListDeploymentVersions(hubId string, deploymentId string) (result []model.DeploymentVersion, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result = []model.DeploymentVersion{}
+	for _, v := range this.versions {
+		if v.HubId == hubId && v.DeploymentId == deploymentId {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+func (this *DatabaseMock) GetDeploymentVersion(hubId string, deploymentId string, version int) (result model.DeploymentVersion, exists bool, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	for _, v := range this.versions {
+		if v.HubId == hubId && v.DeploymentId == deploymentId && v.Version == version {
+			return v, true, nil
+		}
+	}
+	return result, false, nil
+}
+
+func (this *DatabaseMock) RemoveDeploymentVersions(hubId string, deploymentId string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	remaining := []model.DeploymentVersion{}
+	for _, v := range this.versions {
+		if v.HubId != hubId || v.DeploymentId != deploymentId {
+			remaining = append(remaining, v)
+		}
+	}
+	this.versions = remaining
+	return nil
+}
+
+func (this *DatabaseMock) ListTemplates(userId string) (result []model.DeploymentTemplate, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result = []model.DeploymentTemplate{}
+	for _, template := range this.templates {
+		if template.UserId == userId {
+			result = append(result, template)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Id < result[j].Id
+	})
+	return result, nil
+}
+
+func (this *DatabaseMock) GetTemplate(id string) (result model.DeploymentTemplate, exists bool, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	result, exists = this.templates[id]
+	return result, exists, nil
+}
+
+func (this *DatabaseMock) SetTemplate(template model.DeploymentTemplate) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.templates[template.Id] = template
+	return nil
+}
+
+func (this *DatabaseMock) RemoveTemplate(id string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	delete(this.templates, id)
+	return nil
+}
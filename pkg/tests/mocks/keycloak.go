@@ -0,0 +1,41 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mocks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// NewKeycloakMock answers every token exchange with the given token
+func NewKeycloakMock(ctx context.Context, token string) (url string) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		json.NewEncoder(writer).Encode(map[string]interface{}{
+			"access_token": strings.TrimPrefix(token, "Bearer "),
+			"expires_in":   300,
+			"token_type":   "bearer",
+		})
+	}))
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	return server.URL
+}
@@ -28,18 +28,47 @@ import (
 	"strings"
 )
 
+// resources with restricted permissions; every other resource grants every permission
+const (
+	ForbiddenHubId   = "urn:infai:ses:hub:forbidden"    //no permissions
+	ExecuteOnlyHubId = "urn:infai:ses:hub:execute-only" //read and execute
+	ReadOnlyHubId    = "urn:infai:ses:hub:read-only"    //read
+)
+
+var restrictedPermissions = map[string]string{
+	ForbiddenHubId:   "",
+	ExecuteOnlyHubId: "rx",
+	ReadOnlyHubId:    "r",
+}
+
+// hubs listed by AdminListResourceIds
+var HubIds = []string{"urn:infai:ses:hub:114b6d26-5540-44e8-9aeb-234073a49995", "urn:infai:ses:hub:2"}
+
+func hasPermissions(id string, permissions []model.Permission) bool {
+	granted, restricted := restrictedPermissions[id]
+	if !restricted {
+		return true
+	}
+	for _, permission := range permissions {
+		if !strings.ContainsRune(granted, rune(permission)) {
+			return false
+		}
+	}
+	return true
+}
+
 type PermMock struct {
 	Calls *map[string][]string
 }
 
 func (this *PermMock) CheckPermission(token string, topicId string, id string, permissions ...model.Permission) (access bool, err error, code int) {
-	return true, nil, http.StatusOK
+	return hasPermissions(id, permissions), nil, http.StatusOK
 }
 
 func (this *PermMock) CheckMultiplePermissions(token string, topicId string, ids []string, permissions ...model.Permission) (access map[string]bool, err error, code int) {
 	access = map[string]bool{}
 	for _, id := range ids {
-		access[id] = true
+		access[id] = hasPermissions(id, permissions)
 	}
 	return access, nil, http.StatusOK
 }
@@ -75,8 +104,14 @@ func (this *PermMock) SetTopic(token string, topic model.Topic) (result model.To
 }
 
 func (this *PermMock) AdminListResourceIds(tokenStr string, topicId string, options model.ListOptions) (ids []string, err error, code int) {
-	//TODO implement me
-	panic("implement me")
+	if topicId != "hubs" {
+		return []string{}, nil, http.StatusOK
+	}
+	ids = HubIds[min(options.Offset, int64(len(HubIds))):]
+	if options.Limit > 0 {
+		ids = ids[:min(options.Limit, int64(len(ids)))]
+	}
+	return ids, nil, http.StatusOK
 }
 
 func (this *PermMock) AdminLoadFromPermissionSearch(req model.AdminLoadPermSearchRequest) (updateCount int, err error, code int) {
@@ -45,6 +45,11 @@ func NewStatefulRequestMock(ctx context.Context, file string) (url string, calls
 	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 		mux.Lock()
 		defer mux.Unlock()
+		//readiness checks request the root path, they don't consume a response
+		if request.URL.Path == "/" {
+			writer.WriteHeader(http.StatusOK)
+			return
+		}
 		defer func() {
 			count = count + 1
 		}()
@@ -48,8 +48,17 @@ func NewStatelessRepoMock(ctx context.Context, file string) (url string, calls *
 		}
 		path := request.URL.Path
 		log.Println("TEST: receive http request", file, request.Method, path)
+		//readiness checks request the root path
+		if path == "/" {
+			writer.WriteHeader(http.StatusOK)
+			return
+		}
 		callsMap[path] = append(callsMap[path], strings.TrimSpace(string(payload)))
-		response, ok := responses[path]
+		//responses may be bound to a specific query by using path+"?"+query as key
+		response, ok := responses[path+"?"+request.URL.RawQuery]
+		if !ok {
+			response, ok = responses[path]
+		}
 		if !ok {
 			log.Println("ERROR: ", file, "unknown path", path)
 			http.Error(writer, "unknown path", 500)
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tests/mocks"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestHubPermissions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, syncCalls, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	forbiddenUrl := apiUrl + "/deployments/" + url.PathEscape(mocks.ForbiddenHubId)
+	executeOnlyUrl := apiUrl + "/deployments/" + url.PathEscape(mocks.ExecuteOnlyHubId)
+
+	expectForbidden := func(t *testing.T, err error) {
+		if err == nil {
+			t.Error("expected error")
+			return
+		}
+		status, body, _ := strings.Cut(err.Error(), " ")
+		if status != "403" {
+			t.Error(err)
+			return
+		}
+		problem := model.Problem{}
+		err = json.Unmarshal([]byte(body), &problem)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if problem.Code != model.ErrorCodeForbidden {
+			t.Errorf("%#v", problem)
+		}
+	}
+
+	t.Run("deploy", func(t *testing.T) {
+		_, err := jsonRequest[model.DeploymentWithState](http.MethodPost, forbiddenUrl, deploymentmodel.Deployment{Version: deploymentmodel.CurrentVersion, Name: "test"})
+		expectForbidden(t, err)
+	})
+	t.Run("start", func(t *testing.T) {
+		_, err := jsonRequest[model.StartResult](http.MethodPost, forbiddenUrl+"/d1/start", map[string]interface{}{})
+		expectForbidden(t, err)
+	})
+	t.Run("list", func(t *testing.T) {
+		_, err := jsonRequest[[]model.FogDeployment](http.MethodGet, forbiddenUrl, nil)
+		expectForbidden(t, err)
+	})
+	t.Run("remove without administrate permission", func(t *testing.T) {
+		_, err := jsonRequest[model.DeploymentStateInfo](http.MethodDelete, executeOnlyUrl+"/d1", nil)
+		expectForbidden(t, err)
+	})
+	//an update removes the current camunda deployments, like a remove
+	t.Run("update without administrate permission", func(t *testing.T) {
+		_, err := jsonRequest[model.DeploymentWithState](http.MethodPut, executeOnlyUrl+"/d1", deploymentmodel.Deployment{Version: deploymentmodel.CurrentVersion, Name: "test"})
+		expectForbidden(t, err)
+	})
+	t.Run("patch without administrate permission", func(t *testing.T) {
+		_, err := jsonRequest[model.DeploymentWithState](http.MethodPatch, executeOnlyUrl+"/d1", []map[string]interface{}{{"op": "replace", "path": "/name", "value": "test"}})
+		expectForbidden(t, err)
+	})
+	t.Run("copy to forbidden hub", func(t *testing.T) {
+		_, err := jsonRequest[model.DeploymentTransferResult](http.MethodPost, apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d1/copy?target_hub="+url.QueryEscape(mocks.ForbiddenHubId), nil)
+		expectForbidden(t, err)
+	})
+	t.Run("move without administrate permission", func(t *testing.T) {
+		_, err := jsonRequest[model.DeploymentTransferResult](http.MethodPost, executeOnlyUrl+"/d1/copy?move=true&target_hub="+url.QueryEscape(testHubId), nil)
+		expectForbidden(t, err)
+	})
+
+	for path := range *syncCalls {
+		if strings.Contains(path, mocks.ForbiddenHubId) || strings.Contains(path, mocks.ExecuteOnlyHubId) {
+			t.Error("unexpected process-sync call", path)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/api/util"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRateLimit(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	router := httprouter.New()
+	router.GET("/deployments/:hubId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		writer.WriteHeader(http.StatusOK)
+	})
+	router.GET("/prepared-deployments/:hubId/:modelId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		started <- struct{}{}
+		<-release
+		writer.WriteHeader(http.StatusOK)
+	})
+	router.POST("/deployments/:hubId/:id/copy", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		writer.WriteHeader(http.StatusOK)
+	})
+	router.POST("/deployment-imports/:hubId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		writer.WriteHeader(http.StatusOK)
+	})
+	router.POST("/templates/:id/apply/:hubId", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	request := func(server *httptest.Server, method string, path string, withToken bool) (status int, retryAfter string, err error) {
+		req, err := http.NewRequest(method, server.URL+path, nil)
+		if err != nil {
+			return status, retryAfter, err
+		}
+		if withToken {
+			req.Header.Set("Authorization", token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return status, retryAfter, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, resp.Header.Get("Retry-After"), nil
+	}
+
+	expectWithMethod := func(t *testing.T, server *httptest.Server, method string, path string, withToken bool, expectedStatus int) {
+		t.Helper()
+		status, retryAfter, err := request(server, method, path, withToken)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if status != expectedStatus {
+			t.Error(path, status)
+		}
+		if status == http.StatusTooManyRequests && retryAfter == "" {
+			t.Error("missing Retry-After header")
+		}
+	}
+
+	expect := func(t *testing.T, server *httptest.Server, path string, withToken bool, expectedStatus int) {
+		t.Helper()
+		expectWithMethod(t, server, http.MethodGet, path, withToken, expectedStatus)
+	}
+
+	t.Run("user", func(t *testing.T) {
+		server := httptest.NewServer(util.NewRateLimit(router, router, &configuration.ConfigStruct{
+			RateLimitUserRequestsPerSecond: 0.01,
+			RateLimitUserBurst:             2,
+		}))
+		defer server.Close()
+		expect(t, server, "/deployments/h1", true, http.StatusOK)
+		expect(t, server, "/deployments/h2", true, http.StatusOK)
+		expect(t, server, "/deployments/h3", true, http.StatusTooManyRequests)
+		//requests without user are only limited by hub
+		expect(t, server, "/deployments/h1", false, http.StatusOK)
+	})
+
+	t.Run("hub", func(t *testing.T) {
+		server := httptest.NewServer(util.NewRateLimit(router, router, &configuration.ConfigStruct{
+			RateLimitUserRequestsPerSecond: 0.01,
+			RateLimitUserBurst:             3,
+			RateLimitHubRequestsPerSecond:  0.01,
+			RateLimitHubBurst:              1,
+		}))
+		defer server.Close()
+		expect(t, server, "/deployments/h1", true, http.StatusOK)
+		expect(t, server, "/deployments/h1", true, http.StatusTooManyRequests)
+		//the rejected request is not counted against the user limit
+		expect(t, server, "/deployments/h2", true, http.StatusOK)
+		expect(t, server, "/deployments/h3", true, http.StatusOK)
+		expect(t, server, "/deployments/h4", true, http.StatusTooManyRequests)
+	})
+
+	t.Run("concurrent prepares", func(t *testing.T) {
+		server := httptest.NewServer(util.NewRateLimit(router, router, &configuration.ConfigStruct{
+			MaxConcurrentPrepares: 1,
+		}))
+		defer server.Close()
+		done := make(chan error)
+		go func() {
+			status, _, err := request(server, http.MethodGet, "/prepared-deployments/h1/m1", true)
+			if err == nil && status != http.StatusOK {
+				t.Error(status)
+			}
+			done <- err
+		}()
+		<-started
+		expect(t, server, "/prepared-deployments/h2/m1", true, http.StatusTooManyRequests)
+		//imports, copies and applied templates resolve the device selections like preparations
+		expectWithMethod(t, server, http.MethodPost, "/deployment-imports/h2", true, http.StatusTooManyRequests)
+		expectWithMethod(t, server, http.MethodPost, "/deployments/h2/d1/copy", true, http.StatusTooManyRequests)
+		expectWithMethod(t, server, http.MethodPost, "/templates/t1/apply/h2", true, http.StatusTooManyRequests)
+		expect(t, server, "/deployments/h1", true, http.StatusOK)
+		release <- struct{}{}
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+		go func() {
+			<-started
+			release <- struct{}{}
+		}()
+		expect(t, server, "/prepared-deployments/h2/m1", true, http.StatusOK)
+		expectWithMethod(t, server, http.MethodPost, "/deployment-imports/h2", true, http.StatusOK)
+		expectWithMethod(t, server, http.MethodPost, "/deployments/h2/d1/copy", true, http.StatusOK)
+		expectWithMethod(t, server, http.MethodPost, "/templates/t1/apply/h2", true, http.StatusOK)
+	})
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/tests/mocks"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSchedules(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := mocks.NewDatabaseMock()
+	apiUrl, syncCalls, err := startTestApiWithDb(ctx, "resources/metadata.json", db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	schedulesUrl := apiUrl + "/schedules/" + url.PathEscape(testHubId)
+
+	created := model.Schedule{}
+	t.Run("create", func(t *testing.T) {
+		created, err = jsonRequest[model.Schedule](http.MethodPost, schedulesUrl, model.Schedule{
+			DeploymentId: "d1",
+			Interval:     "1h",
+			Inputs:       map[string]interface{}{"name": "x", "count": 7},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if created.Id == "" || created.UserId != "testuser" || created.HubId != testHubId || created.LastRun != nil {
+			t.Errorf("%#v", created)
+		}
+		if created.NextRun.Before(time.Now().Add(59*time.Minute)) || created.NextRun.After(time.Now().Add(time.Hour)) {
+			t.Error(created.NextRun)
+		}
+	})
+
+	t.Run("create invalid", func(t *testing.T) {
+		for _, invalid := range []model.Schedule{
+			{DeploymentId: "d1", Interval: "1s", Inputs: map[string]interface{}{"name": "x"}},
+			{DeploymentId: "d1", Interval: "1h", Cron: "* * * * *", Inputs: map[string]interface{}{"name": "x"}},
+			{DeploymentId: "d1", Cron: "foo", Inputs: map[string]interface{}{"name": "x"}},
+			{DeploymentId: "d1", Interval: "1h"},
+		} {
+			_, err = jsonRequest[model.Schedule](http.MethodPost, schedulesUrl, invalid)
+			if err == nil {
+				t.Errorf("expected error for %#v", invalid)
+			}
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		update := created
+		update.Interval = ""
+		update.Cron = "0 6 * * *"
+		updated, err := jsonRequest[model.Schedule](http.MethodPut, schedulesUrl+"/"+created.Id, update)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if updated.Cron != "0 6 * * *" || updated.NextRun.Hour() != 6 || updated.NextRun.Minute() != 0 {
+			t.Errorf("%#v", updated)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		list, err := jsonRequest[[]model.Schedule](http.MethodGet, schedulesUrl, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(list) != 1 || list[0].Id != created.Id {
+			t.Errorf("%#v", list)
+		}
+	})
+
+	t.Run("run due schedule", func(t *testing.T) {
+		due, exists, _ := db.GetSchedule(created.Id)
+		if !exists {
+			t.Error("missing schedule")
+			return
+		}
+		due.NextRun = time.Now().Add(-time.Minute).Truncate(time.Second).UTC()
+		_ = db.SetSchedule(due)
+		time.Sleep(500 * time.Millisecond)
+
+		result, err := jsonRequest[model.Schedule](http.MethodGet, schedulesUrl+"/"+created.Id, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.LastRun == nil || result.LastError != "" || !result.NextRun.After(time.Now()) {
+			t.Errorf("%#v", result)
+		}
+		if len((*syncCalls)["/deployments/"+testHubId+"/c1/start"]) != 1 || len((*syncCalls)["/deployments/"+testHubId+"/c2/start"]) != 1 {
+			t.Error("expected exactly one start per camunda deployment")
+		}
+	})
+
+	t.Run("delete without execute permission", func(t *testing.T) {
+		readOnly := created
+		readOnly.Id = "read-only-schedule"
+		readOnly.HubId = mocks.ReadOnlyHubId
+		_ = db.SetSchedule(readOnly)
+		_, err := jsonRequest[bool](http.MethodDelete, apiUrl+"/schedules/"+url.PathEscape(mocks.ReadOnlyHubId)+"/"+readOnly.Id, nil)
+		if err == nil || !strings.HasPrefix(err.Error(), "403") {
+			t.Error(err)
+		}
+		if _, exists, _ := db.GetSchedule(readOnly.Id); !exists {
+			t.Error("schedule removed")
+		}
+		_ = db.RemoveSchedule(readOnly.Id)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := jsonRequest[bool](http.MethodDelete, schedulesUrl+"/"+created.Id, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, err = jsonRequest[model.Schedule](http.MethodGet, schedulesUrl+"/"+created.Id, nil)
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func jsonRequest[T any](method string, endpoint string, body interface{}) (result T, err error) {
+	var reader io.Reader
+	if body != nil {
+		temp, err := json.Marshal(body)
+		if err != nil {
+			return result, err
+		}
+		reader = bytes.NewReader(temp)
+	}
+	req, err := http.NewRequest(method, endpoint, reader)
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("Authorization", token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		temp, _ := io.ReadAll(resp.Body)
+		return result, errors.New(strconv.Itoa(resp.StatusCode) + " " + string(temp))
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	return result, err
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/model"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestTemplates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, syncCalls, err := startTestApi(ctx, "resources/metadata.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	prepared, err := jsonRequest[deploymentmodel.Deployment]("GET", apiUrl+"/prepared-deployments/"+url.PathEscape(testHubId)+"/e32329bc-3800-4429-986e-4cc208e95fc2", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	deviceId := "urn:infai:ses:device:dc74369e-89bc-4c7a-ad38-aa4789ea0061"
+	prepared.Name = "heating {{hub_name}}"
+	prepared.Elements[0].Task.Selection.SelectedDeviceId = &deviceId
+
+	template, err := jsonRequest[model.DeploymentTemplate]("POST", apiUrl+"/templates", model.DeploymentTemplate{Deployment: prepared})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if template.Id == "" || template.Name != "heating {{hub_name}}" || template.Deployment.Id != "" {
+		t.Errorf("%#v", template)
+		return
+	}
+	if selection := template.Deployment.Elements[0].Task.Selection; selection.SelectedDeviceId != nil || len(selection.SelectionOptions) != 0 {
+		t.Errorf("%#v", selection)
+		return
+	}
+
+	list, err := jsonRequest[[]model.DeploymentTemplate]("GET", apiUrl+"/templates", nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(list) != 1 || list[0].Id != template.Id {
+		t.Errorf("%#v", list)
+		return
+	}
+
+	result, err := jsonRequest[model.DeploymentTransferResult]("POST", apiUrl+"/templates/"+url.PathEscape(template.Id)+"/apply/"+url.PathEscape(testHubId), nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !result.Deployed || len(result.Unmatched) != 0 || result.State != model.DeploymentStatePendingSync {
+		t.Errorf("%#v", result)
+		return
+	}
+	deployCalls := (*syncCalls)["/deployments/"+testHubId]
+	if len(deployCalls) != 1 {
+		t.Error(deployCalls)
+		return
+	}
+	deployed := deploymentmodel.Deployment{}
+	err = json.Unmarshal([]byte(deployCalls[0]), &deployed)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if deployed.Name != "heating test-hub" {
+		t.Error(deployed.Name)
+	}
+	selection := deployed.Elements[0].Task.Selection
+	if selection.SelectedDeviceId == nil || *selection.SelectedDeviceId != "urn:infai:ses:device:dc74369e-89bc-4c7a-ad38-aa4789ea0060" {
+		t.Errorf("%#v", selection.SelectedDeviceId)
+	}
+	if selection.SelectedServiceId == nil || *selection.SelectedServiceId != "urn:infai:ses:service:39415c76-93a3-4e8d-8740-d1a83c64bddc" {
+		t.Errorf("%#v", selection.SelectedServiceId)
+	}
+
+	_, err = jsonRequest[bool]("DELETE", apiUrl+"/templates/"+url.PathEscape(template.Id), nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = jsonRequest[model.DeploymentTemplate]("GET", apiUrl+"/templates/"+url.PathEscape(template.Id), nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "404") {
+		t.Error(err)
+	}
+}
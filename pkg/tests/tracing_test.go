@@ -0,0 +1,247 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"github.com/SENERGY-Platform/process-deployment/lib/model/deploymentmodel"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/processsync"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// the global tracer provider can only be set once, so every tracing test is a sub test
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Run("prepare", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		apiUrl, _, err := startTestApi(ctx, "resources/metadata.json")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		traceId := "4bf92f3577b34da6a3ce929d0e0e4736"
+		req, err := http.NewRequest("GET", apiUrl+"/prepared-deployments/"+url.PathEscape(testHubId)+"/e32329bc-3800-4429-986e-4cc208e95fc2", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		req.Header.Set("Authorization", token)
+		req.Header.Set("traceparent", "00-"+traceId+"-00f067aa0ba902b7-01")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Error(resp.StatusCode)
+			return
+		}
+
+		spans := map[string]sdktrace.ReadOnlySpan{}
+		for _, span := range recorder.Ended() {
+			if span.SpanContext().TraceID().String() == traceId {
+				spans[span.Name()] = span
+			}
+		}
+		for _, name := range []string{
+			"GET /prepared-deployments/:hubId/:modelId",
+			"prepare deployment",
+			"device-selection bulk-selectables-v2",
+			"device-repository get-hub",
+		} {
+			if _, ok := spans[name]; !ok {
+				t.Error("missing span", name)
+			}
+		}
+		if t.Failed() {
+			t.Logf("%#v", spans)
+			return
+		}
+		if spans["prepare deployment"].Parent().SpanID() != spans["GET /prepared-deployments/:hubId/:modelId"].SpanContext().SpanID() {
+			t.Error("prepare span is not a child of the request span")
+		}
+		if spans["device-repository get-hub"].Parent().SpanID() != spans["device-selection bulk-selectables-v2"].SpanContext().SpanID() {
+			t.Error("get-hub span is not a child of the bulk selection span")
+		}
+	})
+
+	//sends a request with a new trace id and returns the ended spans of this trace by name
+	traced := func(t *testing.T, method string, endpoint string, body interface{}) (spans map[string]sdktrace.ReadOnlySpan) {
+		traceId := trace.TraceID{}
+		_, _ = rand.Read(traceId[:])
+		var reader io.Reader
+		if body != nil {
+			temp, err := json.Marshal(body)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			reader = bytes.NewReader(temp)
+		}
+		req, err := http.NewRequest(method, endpoint, reader)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		req.Header.Set("Authorization", token)
+		req.Header.Set("traceparent", "00-"+traceId.String()+"-00f067aa0ba902b7-01")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Error(resp.StatusCode)
+			return
+		}
+		spans = map[string]sdktrace.ReadOnlySpan{}
+		for _, span := range recorder.Ended() {
+			if span.SpanContext().TraceID() == traceId {
+				spans[span.Name()] = span
+			}
+		}
+		return spans
+	}
+
+	//checks that every named span is part of the trace and descends from the request span
+	expectLinked := func(t *testing.T, spans map[string]sdktrace.ReadOnlySpan, requestSpan string, names ...string) {
+		root, ok := spans[requestSpan]
+		if !ok {
+			t.Errorf("missing request span %v in %#v", requestSpan, spans)
+			return
+		}
+		parents := map[trace.SpanID]trace.SpanID{}
+		for _, span := range spans {
+			parents[span.SpanContext().SpanID()] = span.Parent().SpanID()
+		}
+		for _, name := range names {
+			span, ok := spans[name]
+			if !ok {
+				t.Error("missing span", name)
+				continue
+			}
+			id := span.SpanContext().SpanID()
+			for id.IsValid() && id != root.SpanContext().SpanID() {
+				id = parents[id]
+			}
+			if !id.IsValid() {
+				t.Error("span is not a descendant of the request span", name)
+			}
+		}
+	}
+
+	t.Run("start", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		apiUrl, _, err := startTestApi(ctx, "resources/metadata.json")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		spans := traced(t, "POST", apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d2/start?business_key=bk1", map[string]interface{}{"b": map[string]interface{}{"c": "x"}, "n": 1234567890123456789, "s": "str"})
+		expectLinked(t, spans, "POST /deployments/:hubId/:id/start", "start deployment", "process-sync metadata", "process-sync start")
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		apiUrl, _, err := startTestApi(ctx, "resources/metadata.json")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		spans := traced(t, "DELETE", apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d1", nil)
+		expectLinked(t, spans, "DELETE /deployments/:hubId/:id", "remove deployment", "process-sync metadata", "process-sync remove")
+	})
+
+	t.Run("update", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		apiUrl, _, err := startTestApi(ctx, "resources/metadata.json")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		prepared, err := jsonRequest[deploymentmodel.Deployment]("GET", apiUrl+"/prepared-deployments/"+url.PathEscape(testHubId)+"/e32329bc-3800-4429-986e-4cc208e95fc2", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		deviceId := "urn:infai:ses:device:dc74369e-89bc-4c7a-ad38-aa4789ea0060"
+		serviceId := "urn:infai:ses:service:39415c76-93a3-4e8d-8740-d1a83c64bddc"
+		prepared.Id = ""
+		prepared.Elements[0].Task.Selection.SelectedDeviceId = &deviceId
+		prepared.Elements[0].Task.Selection.SelectedServiceId = &serviceId
+		spans := traced(t, "PUT", apiUrl+"/deployments/"+url.PathEscape(testHubId)+"/d1", prepared)
+		expectLinked(t, spans, "PUT /deployments/:hubId/:id", "update deployment", "process-sync metadata", "process-sync deploy", "process-sync remove")
+	})
+
+	t.Run("propagation", func(t *testing.T) {
+		traceparent := ""
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			traceparent = request.Header.Get("traceparent")
+			writer.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		ctx, span := otel.Tracer("test").Start(context.Background(), "test")
+		err := processsync.New(&configuration.ConfigStruct{ProcessSyncUrl: server.URL}).Deploy(ctx, token, testHubId, deploymentmodel.Deployment{Id: "d1"})
+		span.End()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !strings.Contains(traceparent, span.SpanContext().TraceID().String()) {
+			t.Error(traceparent)
+		}
+
+		var deploySpan sdktrace.ReadOnlySpan
+		for _, ended := range recorder.Ended() {
+			if ended.Name() == "process-sync deploy" && ended.Parent().SpanID() == span.SpanContext().SpanID() {
+				deploySpan = ended
+			}
+		}
+		if deploySpan == nil {
+			t.Error("missing process-sync deploy span")
+			return
+		}
+		if !strings.Contains(traceparent, deploySpan.SpanContext().SpanID().String()) {
+			t.Error("expected deploy span as parent of the upstream request", traceparent)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"github.com/SENERGY-Platform/process-fog-deployment/pkg/configuration"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"log"
+	"net/http"
+	"time"
+)
+
+const ServiceName = "process-fog-deployment"
+
+var tracer = otel.Tracer("github.com/SENERGY-Platform/process-fog-deployment")
+
+// Init sets the global propagator and tracer provider.
+// spans are exported with otlp over http to config.OtelExporterEndpoint; an empty endpoint disables the export
+// but the trace context of incoming requests is still propagated to upstream services.
+// the tracer provider is flushed and shut down when ctx is done.
+func Init(ctx context.Context, config configuration.Config) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if config.OtelExporterEndpoint == "" {
+		return nil
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(config.OtelExporterEndpoint))
+	if err != nil {
+		return err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	go func() {
+		<-ctx.Done()
+		timeout, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := provider.Shutdown(timeout)
+		if err != nil {
+			log.Println("ERROR: unable to shutdown tracer provider", err)
+		}
+	}()
+	return nil
+}
+
+// StartSpan starts a child span of the span in ctx (if any); the span has to be finished with EndSpan
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// EndSpan marks the span as failed if err is set and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// NewTransport returns a http.RoundTripper that adds the trace context of the request context to the request headers
+func NewTransport(next http.RoundTripper) http.RoundTripper {
+	return &transport{next: next}
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+func (this *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context()) //a RoundTripper should not modify the request
+	otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+	return this.next.RoundTrip(req)
+}